@@ -0,0 +1,294 @@
+// Package analytics describes the distribution of base stats over a set of
+// Pokemon, overall and by type and region, and places one Pokemon within it.
+package analytics
+
+import (
+	"GO-Mongo/models"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// stats lists the described stats with their display names, in the games'
+// order, then the base stat total.
+var stats = []struct {
+	name, label string
+	value       func(models.BaseStats) int
+}{
+	{"hp", "HP", func(b models.BaseStats) int { return b.HP }},
+	{"attack", "Attack", func(b models.BaseStats) int { return b.Attack }},
+	{"defense", "Defense", func(b models.BaseStats) int { return b.Defense }},
+	{"sp_attack", "Sp. Atk", func(b models.BaseStats) int { return b.SpAttack }},
+	{"sp_defense", "Sp. Def", func(b models.BaseStats) int { return b.SpDefense }},
+	{"speed", "Speed", func(b models.BaseStats) int { return b.Speed }},
+	{"total", "Total", func(b models.BaseStats) int { return b.Total }},
+}
+
+// Region is a named set of Pokemon, such as the Pokemon of one dataset.
+type Region struct {
+	Name     string
+	Pokemons []models.Pokemon
+}
+
+// Summary describes the values of one stat. Percentiles interpolate linearly
+// between the closest ranks.
+type Summary struct {
+	Stat   string  `json:"stat"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	StdDev float64 `json:"stddev" doc:"Population standard deviation"`
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	P10    float64 `json:"p10"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+}
+
+// Group is the stat distribution of the Pokemon sharing a type or a region.
+type Group struct {
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+	Stats []Summary `json:"stats" doc:"The six stats, then the base stat total"`
+}
+
+// Report is the result of Describe.
+type Report struct {
+	Count   int       `json:"count"`
+	Stats   []Summary `json:"stats" doc:"The six stats, then the base stat total"`
+	Types   []Group   `json:"types" doc:"By type, sorted by name; dual-type Pokemon count in both"`
+	Regions []Group   `json:"regions" doc:"In the order the regions are served"`
+}
+
+// Ranking places a Pokemon's stat among a set of Pokemon.
+type Ranking struct {
+	Stat  string `json:"stat"`
+	Value int    `json:"value"`
+	Rank  int    `json:"rank" doc:"1 for the highest value; ties share a rank"`
+	Of    int    `json:"of"`
+	// Percentile counts the Pokemon below the value and half of those
+	// equal to it, so the same value always gets the same percentile.
+	Percentile float64 `json:"percentile" doc:"Share of the set below the value, ties counted half, 0-100"`
+	Label      string  `json:"label" doc:"e.g. Speed: 97th percentile in Kanto"`
+}
+
+// Scope is a set of Pokemon a Pokemon is ranked in.
+type Scope struct {
+	Scope    string    `json:"scope" doc:"region, type or all"`
+	Name     string    `json:"name"`
+	Count    int       `json:"count"`
+	Rankings []Ranking `json:"rankings" doc:"The six stats, then the base stat total"`
+}
+
+// Scopes of a ranking.
+const (
+	ScopeRegion = "region"
+	ScopeType   = "type"
+	ScopeAll    = "all"
+)
+
+// Rankings is the result of Rank.
+type Rankings struct {
+	DexNumber string  `json:"dex_number"`
+	Name      string  `json:"name"`
+	Region    string  `json:"region"`
+	Scopes    []Scope `json:"scopes" doc:"The Pokemon's region, each of its types, then every region"`
+}
+
+// Describe summarizes the stats of every Pokemon of regions.
+func Describe(regions []Region) Report {
+	all := flatten(regions)
+	r := Report{Count: len(all), Stats: summarizeStats(all), Types: []Group{}, Regions: []Group{}}
+
+	byType := map[string][]models.Pokemon{}
+	for _, p := range all {
+		for _, t := range p.Types() {
+			byType[t] = append(byType[t], p)
+		}
+	}
+	for _, t := range sortedKeys(byType) {
+		r.Types = append(r.Types, Group{Name: t, Count: len(byType[t]), Stats: summarizeStats(byType[t])})
+	}
+	for _, region := range regions {
+		r.Regions = append(r.Regions, Group{Name: region.Name, Count: len(region.Pokemons), Stats: summarizeStats(region.Pokemons)})
+	}
+	return r
+}
+
+// Rank places p, a Pokemon of the region named region, among the Pokemon of
+// that region, among those sharing each of its types, and among all of them.
+func Rank(p models.Pokemon, region string, regions []Region) Rankings {
+	all := flatten(regions)
+	out := Rankings{DexNumber: p.DexNumber, Name: p.Name, Region: region}
+	for _, r := range regions {
+		if r.Name == region {
+			out.Scopes = append(out.Scopes, scope(p, ScopeRegion, r.Name, "in "+title(r.Name), r.Pokemons))
+		}
+	}
+	for _, t := range p.Types() {
+		var same []models.Pokemon
+		for _, other := range all {
+			if slices.Contains(other.Types(), t) {
+				same = append(same, other)
+			}
+		}
+		out.Scopes = append(out.Scopes, scope(p, ScopeType, t, "among "+t+" types", same))
+	}
+	out.Scopes = append(out.Scopes, scope(p, ScopeAll, ScopeAll, "overall", all))
+	return out
+}
+
+func scope(p models.Pokemon, kind, name, where string, pokemons []models.Pokemon) Scope {
+	s := Scope{Scope: kind, Name: name, Count: len(pokemons), Rankings: make([]Ranking, 0, len(stats))}
+	base := p.BaseStats()
+	for _, stat := range stats {
+		values := column(pokemons, stat.value)
+		v := stat.value(base)
+		pct := PercentileRank(v, values)
+		s.Rankings = append(s.Rankings, Ranking{
+			Stat:       stat.name,
+			Value:      v,
+			Rank:       rank(v, values),
+			Of:         len(values),
+			Percentile: pct,
+			Label:      stat.label + ": " + Ordinal(int(pct)) + " percentile " + where,
+		})
+	}
+	return s
+}
+
+func summarizeStats(pokemons []models.Pokemon) []Summary {
+	out := make([]Summary, 0, len(stats))
+	for _, stat := range stats {
+		s := Summarize(column(pokemons, stat.value))
+		s.Stat = stat.name
+		out = append(out, s)
+	}
+	return out
+}
+
+// Summarize summarizes values; every field is 0 when values is empty.
+func Summarize(values []int) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	sum := 0
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := float64(sum) / float64(len(sorted))
+	var squares float64
+	for _, v := range sorted {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return Summary{
+		Mean:   round(mean),
+		Median: Percentile(sorted, 50),
+		StdDev: round(math.Sqrt(squares / float64(len(sorted)))),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		P10:    Percentile(sorted, 10),
+		P25:    Percentile(sorted, 25),
+		P75:    Percentile(sorted, 75),
+		P90:    Percentile(sorted, 90),
+	}
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted, interpolating
+// linearly between the closest ranks.
+func Percentile(sorted []int, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := min(lo+1, len(sorted)-1)
+	return round(float64(sorted[lo]) + (pos-float64(lo))*float64(sorted[hi]-sorted[lo]))
+}
+
+// PercentileRank returns the share of values below v plus half of those equal
+// to it, as a percentage.
+func PercentileRank(v int, values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var below, equal int
+	for _, x := range values {
+		switch {
+		case x < v:
+			below++
+		case x == v:
+			equal++
+		}
+	}
+	return round((float64(below) + float64(equal)/2) * 100 / float64(len(values)))
+}
+
+// Ordinal formats n as 1st, 2nd, 3rd, 4th, 11th, 21st...
+func Ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// rank is 1 plus the number of values above v.
+func rank(v int, values []int) int {
+	r := 1
+	for _, x := range values {
+		if x > v {
+			r++
+		}
+	}
+	return r
+}
+
+func column(pokemons []models.Pokemon, value func(models.BaseStats) int) []int {
+	out := make([]int, len(pokemons))
+	for i, p := range pokemons {
+		out[i] = value(p.BaseStats())
+	}
+	return out
+}
+
+func flatten(regions []Region) []models.Pokemon {
+	var all []models.Pokemon
+	for _, r := range regions {
+		all = append(all, r.Pokemons...)
+	}
+	return all
+}
+
+func sortedKeys(m map[string][]models.Pokemon) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// title capitalizes a region name for labels: kanto becomes Kanto.
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// round keeps two decimals.
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,123 @@
+package analytics
+
+import (
+	"GO-Mongo/internal/fixture"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	got := Summarize([]int{40, 10, 30, 20, 50})
+	want := Summary{Mean: 30, Median: 30, StdDev: 14.14, Min: 10, Max: 50, P10: 14, P25: 20, P75: 40, P90: 46}
+	if got != want {
+		t.Errorf("Summarize = %+v, want %+v", got, want)
+	}
+	if got := Summarize(nil); got != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero", got)
+	}
+}
+
+func TestPercentileRank(t *testing.T) {
+	values := []int{10, 20, 20, 30}
+	tests := []struct {
+		v    int
+		want float64
+	}{
+		{5, 0},
+		{10, 12.5},
+		{20, 50},
+		{30, 87.5},
+		{40, 100},
+	}
+	for _, tt := range tests {
+		if got := PercentileRank(tt.v, values); got != tt.want {
+			t.Errorf("PercentileRank(%d) = %g, want %g", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{0: "0th", 1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 97: "97th", 100: "100th"} {
+		if got := Ordinal(n); got != want {
+			t.Errorf("Ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestDescribeCountsBothTypes(t *testing.T) {
+	regions := []Region{
+		{Name: "kanto", Pokemons: fixture.Dataset(t, "kanto")},
+		{Name: "johto", Pokemons: fixture.Dataset(t, "johto")},
+	}
+	report := Describe(regions)
+	if report.Count != 251 || report.Regions[0].Count != 151 || report.Regions[1].Count != 100 {
+		t.Errorf("counts = %d, kanto %d, johto %d, want 251, 151 and 100", report.Count, report.Regions[0].Count, report.Regions[1].Count)
+	}
+	counts := map[string]int{}
+	for _, g := range report.Types {
+		counts[g.Name] = g.Count
+	}
+	// Flying is mostly a second type, so it would count 7 from type_01 only.
+	for typ, want := range map[string]int{"Flying": 38, "Fire": 22, "Water": 50, "Steel": 6, "Dragon": 4} {
+		if counts[typ] != want {
+			t.Errorf("%s count = %d, want %d", typ, counts[typ], want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	kanto := fixture.Dataset(t, "kanto")
+	regions := []Region{
+		{Name: "kanto", Pokemons: kanto},
+		{Name: "johto", Pokemons: fixture.Dataset(t, "johto")},
+	}
+	charizard := kanto[5]
+	if charizard.Name != "Charizard" {
+		t.Fatalf("kanto[5] = %s, want Charizard", charizard.Name)
+	}
+
+	r := Rank(charizard, "kanto", regions)
+	var scopes []string
+	for _, s := range r.Scopes {
+		scopes = append(scopes, s.Name+"/"+strconv.Itoa(s.Count))
+	}
+	if want := []string{"kanto/151", "Fire/22", "Flying/38", "all/251"}; !slices.Equal(scopes, want) {
+		t.Fatalf("scopes = %v, want %v", scopes, want)
+	}
+
+	// Every stat of Charizard differs, so a ranking of the wrong stat
+	// shows up as a wrong value.
+	want := []struct {
+		scope int
+		stat  string
+		value int
+		rank  int
+		pct   float64
+	}{
+		{0, "hp", 78, 41, 73.18},
+		{0, "attack", 84, 48, 68.21},
+		{0, "defense", 78, 51, 66.23},
+		{0, "sp_attack", 109, 14, 91.06},
+		{0, "sp_defense", 85, 29, 78.81},
+		{0, "speed", 100, 15, 87.75},
+		{0, "total", 534, 11, 93.05},
+		{1, "speed", 100, 2, 86.36},
+		{2, "sp_attack", 109, 4, 90.79},
+		{3, "speed", 100, 21, 89.44},
+	}
+	for _, w := range want {
+		var got Ranking
+		for _, rk := range r.Scopes[w.scope].Rankings {
+			if rk.Stat == w.stat {
+				got = rk
+			}
+		}
+		if got.Value != w.value || got.Rank != w.rank || got.Percentile != w.pct {
+			t.Errorf("%s %s = %+v, want value %d, rank %d, percentile %g", r.Scopes[w.scope].Name, w.stat, got, w.value, w.rank, w.pct)
+		}
+	}
+	if got := r.Scopes[0].Rankings[5].Label; got != "Speed: 87th percentile in Kanto" {
+		t.Errorf("kanto speed label = %q", got)
+	}
+}
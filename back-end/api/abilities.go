@@ -0,0 +1,91 @@
+package api
+
+import (
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// abilityHandler serves the abilities collection and the Pokemon of every
+// region that have each ability.
+type abilityHandler struct {
+	abilities repository.AbilityRepository
+	regions   []gql.Region
+}
+
+// AbilityHolder is a Pokemon with a given ability.
+type AbilityHolder struct {
+	DexNumber string `json:"dex_number"`
+	Name      string `json:"name"`
+	Hidden    bool   `json:"hidden" doc:"True when it is the Pokemon's hidden ability"`
+}
+
+// AbilityDetail is an ability and the Pokemon that have it.
+type AbilityDetail struct {
+	models.Ability
+	Pokemon []AbilityHolder `json:"pokemon" doc:"In dex order"`
+}
+
+func (h *abilityHandler) register(rt documented) {
+	tags := []string{"abilities"}
+
+	rt.GET("/abilities", &openapi.Operation{
+		OperationID: "listAbilities",
+		Summary:     "Every ability with its description and the generation it was introduced in",
+		Tags:        tags,
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Abilities sorted by name", rt.spec.SchemaOf([]models.Ability{}), "")}),
+	}, h.listAbilities)
+
+	rt.GET("/abilities/:name", &openapi.Operation{
+		OperationID: "getAbility",
+		Summary:     "An ability and every Pokemon that has it, as a regular or hidden ability",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{openapi.PathParam("name", "Ability name, case-insensitive, e.g. Overgrow", openapi.String())},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The ability", rt.spec.SchemaOf(AbilityDetail{}), "")}),
+	}, h.getAbility)
+}
+
+func (h *abilityHandler) listAbilities(c *gin.Context) {
+	abilities, err := h.abilities.Abilities(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	renderer.Render(c, http.StatusOK, abilities)
+}
+
+func (h *abilityHandler) getAbility(c *gin.Context) {
+	name := c.Param("name")
+	ability, err := h.abilities.GetAbility(c.Request.Context(), name)
+	if err != nil {
+		_ = c.Error(notFoundAs(err, "Ability "+strconv.Quote(name)+" not found"))
+		return
+	}
+
+	holders := []AbilityHolder{}
+	for _, region := range h.regions {
+		pokemons, err := region.Pokemon.List(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for _, p := range pokemons {
+			switch {
+			case slices.ContainsFunc(p.Abilities(), func(a string) bool { return strings.EqualFold(a, ability.Name) }):
+				holders = append(holders, AbilityHolder{DexNumber: p.DexNumber, Name: p.Name})
+			case strings.EqualFold(strings.TrimSpace(p.HiddenAbility), ability.Name):
+				holders = append(holders, AbilityHolder{DexNumber: p.DexNumber, Name: p.Name, Hidden: true})
+			}
+		}
+	}
+	slices.SortStableFunc(holders, func(a, b AbilityHolder) int { return strings.Compare(a.DexNumber, b.DexNumber) })
+
+	renderer.Render(c, http.StatusOK, AbilityDetail{Ability: ability, Pokemon: holders})
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"GO-Mongo/analytics"
+	"GO-Mongo/apierror"
+	"GO-Mongo/gql"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// analyticsHandler describes the stat distribution of every region.
+type analyticsHandler struct {
+	regions []gql.Region
+}
+
+func (h *analyticsHandler) register(rt documented) {
+	tags := []string{"analytics"}
+
+	rt.GET("/analytics/stats", &openapi.Operation{
+		OperationID: "getStatAnalytics",
+		Summary:     "Mean, median, standard deviation and percentiles of each base stat, overall, by type and by region",
+		Tags:        tags,
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The distribution", rt.spec.SchemaOf(analytics.Report{}), "")}),
+	}, h.getStats)
+
+	rt.GET("/pokemon/:id/rankings", &openapi.Operation{
+		OperationID: "getPokemonRankings",
+		Summary:     "Rank and percentile of each base stat of a Pokemon in its region, among its types and overall",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{openapi.PathParam("id", "National Dex number, e.g. 25", openapi.Integer())},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The rankings", rt.spec.SchemaOf(analytics.Rankings{}), "")}),
+	}, h.getRankings)
+}
+
+func (h *analyticsHandler) getStats(c *gin.Context) {
+	regions, ok := h.load(c)
+	if !ok {
+		return
+	}
+	renderer.Render(c, http.StatusOK, analytics.Describe(regions))
+}
+
+func (h *analyticsHandler) getRankings(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		_ = c.Error(apierror.InvalidParam("id", "must be a positive dex number"))
+		return
+	}
+
+	for _, region := range h.regions {
+		pokemon, err := region.Pokemon.GetByDexNumber(c.Request.Context(), id)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		regions, ok := h.load(c)
+		if !ok {
+			return
+		}
+		renderer.Render(c, http.StatusOK, analytics.Rank(pokemon, region.Name, regions))
+		return
+	}
+	_ = c.Error(apierror.NotFound("Pokemon #" + idStr + " not found"))
+}
+
+// load lists the Pokemon of every region.
+func (h *analyticsHandler) load(c *gin.Context) ([]analytics.Region, bool) {
+	out := make([]analytics.Region, 0, len(h.regions))
+	for _, region := range h.regions {
+		pokemons, err := region.Pokemon.List(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return nil, false
+		}
+		out = append(out, analytics.Region{Name: region.Name, Pokemons: pokemons})
+	}
+	return out, true
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"GO-Mongo/gql"
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/repository"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter returns the real router over the Kanto dataset, served as
+// the REST repository, and both the Kanto and Johto datasets as regions.
+// change, when not nil, adjusts the options first.
+func newTestRouter(t *testing.T, change func(*Options)) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	kanto := repository.NewMemory(fixture.Dataset(t, "kanto"))
+	opts := Options{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Pokemon: kanto,
+		Regions: []gql.Region{
+			{Name: "kanto", Pokemon: kanto},
+			{Name: "johto", Pokemon: repository.NewMemory(fixture.Dataset(t, "johto"))},
+		},
+	}
+	if change != nil {
+		change(&opts)
+	}
+	r, err := NewRouter(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// serve sends a request with an optional JSON body to r and returns the
+// recorded response.
+func serve(r http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limits of one batch lookup.
+const (
+	maxBatchSize = 100
+	maxBatchBody = 64 << 10
+)
+
+// batchID is a dex number or a name; clients may send either as a JSON
+// number or a string.
+type batchID string
+
+func (id *batchID) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err == nil {
+		*id = batchID(n.String())
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.New("must be a dex number or a name")
+	}
+	*id = batchID(s)
+	return nil
+}
+
+// BatchRequest is the body of POST /pokemon/batch.
+type BatchRequest struct {
+	IDs []batchID `json:"ids" doc:"Dex numbers (25 or #0025) and names, at most 100"`
+}
+
+// batchResponse lists the Pokemon found in request order and the identifiers
+// that matched nothing.
+type batchResponse struct {
+	Pokemon  any      `json:"pokemon"`
+	NotFound []string `json:"not_found"`
+}
+
+// batchSchema documents batchResponse for the version's Pokemon model.
+func batchSchema(spec *openapi.Document, model any) *openapi.Schema {
+	return &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"pokemon":   {Type: "array", Items: spec.SchemaOf(model)},
+			"not_found": {Type: "array", Items: openapi.String()},
+		},
+		Required: []string{"pokemon", "not_found"},
+	}
+}
+
+func (h *pokemonHandler) getBatch(c *gin.Context) {
+	raw := c.Query("ids")
+	if strings.TrimSpace(raw) == "" {
+		_ = c.Error(apierror.InvalidParam("ids", "is required"))
+		return
+	}
+	h.lookup(c, strings.Split(raw, ","))
+}
+
+func (h *pokemonHandler) postBatch(c *gin.Context) {
+	var req BatchRequest
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBody)
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		_ = c.Error(apierror.InvalidInput("Request body must be a JSON object with an ids array of dex numbers and names"))
+		return
+	}
+	ids := make([]string, len(req.IDs))
+	for i, id := range req.IDs {
+		ids[i] = string(id)
+	}
+	h.lookup(c, ids)
+}
+
+// lookup resolves ids with one repository query. Unknown identifiers are
+// reported in not_found rather than failing the request.
+func (h *pokemonHandler) lookup(c *gin.Context, ids []string) {
+	if len(ids) == 0 {
+		_ = c.Error(apierror.InvalidParam("ids", "must not be empty"))
+		return
+	}
+	if len(ids) > maxBatchSize {
+		_ = c.Error(apierror.InvalidParam("ids", "must list at most "+strconv.Itoa(maxBatchSize)+" identifiers"))
+		return
+	}
+	for i, id := range ids {
+		ids[i] = strings.TrimSpace(id)
+		if ids[i] == "" {
+			_ = c.Error(apierror.InvalidParam("ids", "must not contain empty identifiers"))
+			return
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(ids[i], "#")); err == nil && n < 1 {
+			_ = c.Error(apierror.InvalidParam("ids", strconv.Quote(ids[i])+" is not a positive dex number"))
+			return
+		}
+	}
+
+	result, err := h.repo.Lookup(c.Request.Context(), ids)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, batchResponse{
+		Pokemon:  h.version.pokemonList(result.Found),
+		NotFound: result.Missing,
+	})
+}
+
+// lookupAll resolves ids like lookup but requires every identifier to match;
+// the unmatched ones are reported as invalid values of field.
+func lookupAll(c *gin.Context, repo repository.PokemonRepository, field string, ids []string) ([]models.Pokemon, bool) {
+	result, err := repo.Lookup(c.Request.Context(), ids)
+	if err != nil {
+		_ = c.Error(err)
+		return nil, false
+	}
+	if len(result.Missing) > 0 {
+		details := make([]apierror.Detail, len(result.Missing))
+		for i, id := range result.Missing {
+			details[i] = apierror.Detail{Field: field, Reason: strconv.Quote(id) + " matches no Pokemon"}
+		}
+		_ = c.Error(apierror.InvalidInput("Some identifiers match no Pokemon", details...))
+		return nil, false
+	}
+	return result.Found, true
+}
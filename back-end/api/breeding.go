@@ -0,0 +1,100 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/breeding"
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// breedingHandler serves egg groups and breeding partners. Breeding works
+// across regions, so every region's Pokemon are considered.
+type breedingHandler struct {
+	regions []gql.Region
+}
+
+func (h *breedingHandler) register(rt documented) {
+	tags := []string{"breeding"}
+
+	rt.GET("/egg-groups", &openapi.Operation{
+		OperationID: "listEggGroups",
+		Summary:     "Egg groups and their members",
+		Tags:        tags,
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Egg groups sorted by name", rt.spec.SchemaOf([]breeding.Group{}), "")}),
+	}, h.listEggGroups)
+
+	with := openapi.QueryParam("with", "Dex number or name of the Pokemon to breed, e.g. 25 or pikachu", openapi.String())
+	with.Required = true
+	rt.GET("/breeding/compatible", &openapi.Operation{
+		OperationID: "getBreedingPartners",
+		Summary:     "Pokemon that can breed with a given one",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{with},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The breeding partners", rt.spec.SchemaOf(breeding.Compatibility{}), "")}),
+	}, h.getCompatible)
+}
+
+func (h *breedingHandler) listEggGroups(c *gin.Context) {
+	pokemons, ok := h.all(c)
+	if !ok {
+		return
+	}
+	renderer.Render(c, http.StatusOK, breeding.Groups(pokemons))
+}
+
+func (h *breedingHandler) getCompatible(c *gin.Context) {
+	with := strings.TrimSpace(c.Query("with"))
+	if with == "" {
+		_ = c.Error(apierror.InvalidParam("with", "is required"))
+		return
+	}
+	pokemons, ok := h.all(c)
+	if !ok {
+		return
+	}
+
+	var target *models.Pokemon
+	for _, region := range h.regions {
+		result, err := region.Pokemon.Lookup(c.Request.Context(), []string{with})
+		if errors.Is(err, repository.ErrInvalidInput) {
+			_ = c.Error(apierror.InvalidParam("with", "must be a positive dex number or a name"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if len(result.Found) > 0 {
+			target = &result.Found[0]
+			break
+		}
+	}
+	if target == nil {
+		_ = c.Error(apierror.NotFound("Pokemon " + strconv.Quote(with) + " not found"))
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, breeding.Compatible(*target, pokemons))
+}
+
+// all lists the Pokemon of every region.
+func (h *breedingHandler) all(c *gin.Context) ([]models.Pokemon, bool) {
+	var out []models.Pokemon
+	for _, region := range h.regions {
+		pokemons, err := region.Pokemon.List(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return nil, false
+		}
+		out = append(out, pokemons...)
+	}
+	return out, true
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/compare"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compareHandler compares Pokemon side by side.
+type compareHandler struct {
+	repo repository.PokemonRepository
+}
+
+func (h *compareHandler) register(rt documented) {
+	ids := openapi.QueryParam("ids", "Comma-separated dex numbers and names, 2 to 6, e.g. 3,6,9", openapi.String())
+	ids.Required = true
+
+	rt.GET("/pokemon/compare", &openapi.Operation{
+		OperationID: "comparePokemon",
+		Summary:     "Compare the stats and type matchups of 2 to 6 Pokemon",
+		Tags:        []string{"pokemon"},
+		Parameters: []openapi.Parameter{
+			ids,
+			genParam(),
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The comparison", rt.spec.SchemaOf(compare.Comparison{}), "")}),
+	}, h.compare)
+}
+
+func (h *compareHandler) compare(c *gin.Context) {
+	chart, _, ok := chartParam(c)
+	if !ok {
+		return
+	}
+
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < compare.MinPokemon || len(ids) > compare.MaxPokemon {
+		_ = c.Error(apierror.InvalidParam("ids", "must list "+strconv.Itoa(compare.MinPokemon)+" to "+strconv.Itoa(compare.MaxPokemon)+" Pokemon"))
+		return
+	}
+
+	pokemons, ok := lookupAll(c, h.repo, "ids", ids)
+	if !ok {
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, compare.Compare(chart, pokemons))
+}
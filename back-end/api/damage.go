@@ -0,0 +1,203 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/damage"
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"GO-Mongo/stats"
+	"GO-Mongo/typechart"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// damageHandler runs the damage formula between two Pokemon of any region.
+type damageHandler struct {
+	regions []gql.Region
+}
+
+// DamageMove is the move used in a damage calculation.
+type DamageMove struct {
+	Power    int    `json:"power" doc:"Base power, e.g. 90"`
+	Type     string `json:"type" doc:"e.g. Electric"`
+	Category string `json:"category" doc:"physical or special"`
+}
+
+// DamageRequest is the body of POST /calc/damage.
+type DamageRequest struct {
+	Attacker batchID    `json:"attacker" doc:"Dex number or name"`
+	Defender batchID    `json:"defender" doc:"Dex number or name"`
+	Level    int        `json:"level" doc:"Level of both Pokemon, 1-100 (default: 50)"`
+	Move     DamageMove `json:"move"`
+	Critical bool       `json:"critical"`
+}
+
+// DamageSide is one of the two Pokemon with the stat the formula used.
+type DamageSide struct {
+	DexNumber string   `json:"dex_number"`
+	Name      string   `json:"name"`
+	Types     []string `json:"types"`
+	Stat      int      `json:"stat" doc:"Attack or Sp. Atk of the attacker, Defense or Sp. Def of the defender"`
+	HP        int      `json:"hp"`
+}
+
+// DamageResult is the damage range of a move and its share of the
+// defender's HP.
+type DamageResult struct {
+	Attacker      DamageSide `json:"attacker"`
+	Defender      DamageSide `json:"defender"`
+	Level         int        `json:"level"`
+	Move          DamageMove `json:"move"`
+	STAB          bool       `json:"stab" doc:"The move's type is one of the attacker's types"`
+	Effectiveness float64    `json:"effectiveness" doc:"Multiplier against the defender's types"`
+	Critical      bool       `json:"critical"`
+	damage.Result
+	MinPercent float64 `json:"min_percent" doc:"Min as a percentage of the defender's HP"`
+	MaxPercent float64 `json:"max_percent" doc:"Max as a percentage of the defender's HP"`
+}
+
+func (h *damageHandler) register(rt documented) {
+	rt.POST("/calc/damage", &openapi.Operation{
+		OperationID: "calculateDamage",
+		Summary:     "Damage range of a move between two Pokemon, with STAB, type effectiveness and critical hits",
+		Tags:        []string{"calc"},
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]openapi.MediaType{"application/json": {Schema: rt.spec.SchemaOf(DamageRequest{})}},
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The damage range", rt.spec.SchemaOf(DamageResult{}), "")}),
+	}, h.calculate)
+}
+
+func (h *damageHandler) calculate(c *gin.Context) {
+	var req DamageRequest
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBody)
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		_ = c.Error(apierror.InvalidInput("Request body must be a JSON object with attacker, defender and move"))
+		return
+	}
+
+	if req.Level == 0 {
+		req.Level = defaultLevel
+	}
+	if req.Level < 1 || req.Level > 100 {
+		_ = c.Error(apierror.InvalidParam("level", "must be between 1 and 100"))
+		return
+	}
+	if req.Move.Power < 1 {
+		_ = c.Error(apierror.InvalidParam("move.power", "must be a positive number"))
+		return
+	}
+	chart := typechart.Latest()
+	moveType, ok := chart.Canonical(req.Move.Type)
+	if !ok {
+		_ = c.Error(apierror.InvalidParam("move.type", strconv.Quote(req.Move.Type)+" is not a type"))
+		return
+	}
+	req.Move.Type = moveType
+	req.Move.Category = strings.ToLower(strings.TrimSpace(req.Move.Category))
+	if req.Move.Category != models.CategoryPhysical && req.Move.Category != models.CategorySpecial {
+		_ = c.Error(apierror.InvalidParam("move.category", "must be physical or special"))
+		return
+	}
+
+	attacker, ok := h.pokemon(c, "attacker", req.Attacker)
+	if !ok {
+		return
+	}
+	defender, ok := h.pokemon(c, "defender", req.Defender)
+	if !ok {
+		return
+	}
+
+	effectiveness, err := chart.Effectiveness(moveType, defender.Types()...)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	att, ok := actualStats(c, attacker, req.Level)
+	if !ok {
+		return
+	}
+	def, ok := actualStats(c, defender, req.Level)
+	if !ok {
+		return
+	}
+	a, d := att.Attack, def.Defense
+	if req.Move.Category == models.CategorySpecial {
+		a, d = att.SpAttack, def.SpDefense
+	}
+	out := DamageResult{
+		Attacker:      side(attacker, a, att.HP),
+		Defender:      side(defender, d, def.HP),
+		Level:         req.Level,
+		Move:          req.Move,
+		STAB:          slices.Contains(attacker.Types(), moveType),
+		Effectiveness: effectiveness,
+		Critical:      req.Critical,
+	}
+
+	out.Result, err = damage.Calculate(damage.Input{
+		Level:         req.Level,
+		Power:         req.Move.Power,
+		Attack:        out.Attacker.Stat,
+		Defense:       out.Defender.Stat,
+		STAB:          out.STAB,
+		Effectiveness: effectiveness,
+		Critical:      req.Critical,
+	})
+	if err != nil {
+		_ = c.Error(apierror.InvalidInput(err.Error()))
+		return
+	}
+	out.MinPercent = damage.Percent(out.Min, out.Defender.HP)
+	out.MaxPercent = damage.Percent(out.Max, out.Defender.HP)
+	renderer.Render(c, http.StatusOK, out)
+}
+
+// pokemon resolves one side of the calculation.
+func (h *damageHandler) pokemon(c *gin.Context, field string, id batchID) (models.Pokemon, bool) {
+	if strings.TrimSpace(string(id)) == "" {
+		_ = c.Error(apierror.InvalidParam(field, "is required"))
+		return models.Pokemon{}, false
+	}
+	p, err := findPokemon(c.Request.Context(), h.regions, strings.TrimSpace(string(id)))
+	if errors.Is(err, repository.ErrNotFound) {
+		_ = c.Error(apierror.InvalidInput("Some identifiers match no Pokemon",
+			apierror.Detail{Field: field, Reason: strconv.Quote(string(id)) + " matches no Pokemon"}))
+		return models.Pokemon{}, false
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return models.Pokemon{}, false
+	}
+	return p, true
+}
+
+func side(p models.Pokemon, stat, hp int) DamageSide {
+	return DamageSide{DexNumber: p.DexNumber, Name: p.Name, Types: p.Types(), Stat: stat, HP: hp}
+}
+
+// actualStats computes p's stats at level with perfect IVs, no EVs and a
+// neutral nature.
+func actualStats(c *gin.Context, p models.Pokemon, level int) (stats.Values, bool) {
+	v, err := stats.Calculate(stats.Input{
+		Generation: stats.LatestGeneration,
+		Level:      level,
+		Base:       stats.FromBase(p.BaseStats()),
+		IVs:        stats.Uniform(stats.MaxIV),
+	})
+	if err != nil {
+		_ = c.Error(statError(err))
+		return stats.Values{}, false
+	}
+	return v, true
+}
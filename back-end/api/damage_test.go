@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestDamageAcrossRegions(t *testing.T) {
+	r := newTestRouter(t, nil)
+	move := `"move":{"power":90,"type":"Fire","category":"special"}`
+	tests := []struct {
+		body               string
+		attacker, defender string
+	}{
+		{`{"attacker":155,"defender":"chikorita",` + move + `}`, "Cyndaquil", "Chikorita"},
+		{`{"attacker":"charizard","defender":152,` + move + `}`, "Charizard", "Chikorita"},
+	}
+	for _, tt := range tests {
+		w := serve(r, http.MethodPost, "/api/calc/damage", tt.body)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status %d: %s", tt.body, w.Code, w.Body)
+			continue
+		}
+		var got DamageResult
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Attacker.Name != tt.attacker || got.Defender.Name != tt.defender || !got.STAB || got.Effectiveness != 2 {
+			t.Errorf("%s = %+v", tt.body, got)
+		}
+	}
+
+	w := serve(r, http.MethodPost, "/api/calc/damage", `{"attacker":252,"defender":1,`+move+`}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown attacker: status %d, want 400", w.Code)
+	}
+}
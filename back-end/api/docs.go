@@ -0,0 +1,67 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/openapi"
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerHTML loads Swagger UI pointed at the sibling openapi.json.
+//
+//go:embed swagger.html
+var swaggerHTML []byte
+
+// documented registers handlers on a route group and records their OpenAPI
+// operation, so the served document is built from the same calls that
+// build the router.
+type documented struct {
+	group *gin.RouterGroup
+	spec  *openapi.Document
+
+	operationSuffix string
+	deprecated      bool
+}
+
+// versioned returns d documenting its operations as part of version v.
+func (d documented) versioned(v apiVersion) documented {
+	d.operationSuffix = v.operationSuffix
+	d.deprecated = v.deprecated
+	return d
+}
+
+func (d documented) GET(path string, op *openapi.Operation, handlers ...gin.HandlerFunc) {
+	d.handle(http.MethodGet, path, op, handlers...)
+}
+
+func (d documented) POST(path string, op *openapi.Operation, handlers ...gin.HandlerFunc) {
+	d.handle(http.MethodPost, path, op, handlers...)
+}
+
+func (d documented) handle(method, path string, op *openapi.Operation, handlers ...gin.HandlerFunc) {
+	if op.Responses == nil {
+		op.Responses = map[string]openapi.Response{}
+	}
+	if _, ok := op.Responses["default"]; !ok {
+		op.Responses["default"] = openapi.Response{
+			Description: "Error described as RFC 7807 problem details",
+			Content:     map[string]openapi.MediaType{apierror.ContentType: {Schema: d.spec.SchemaOf(apierror.Problem{})}},
+		}
+	}
+	op.OperationID += d.operationSuffix
+	op.Deprecated = op.Deprecated || d.deprecated
+	d.group.Handle(method, path, handlers...)
+	d.spec.Add(method, d.group.BasePath()+path, op)
+}
+
+func serveSpec(spec *openapi.Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, spec)
+	}
+}
+
+func serveSwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerHTML)
+}
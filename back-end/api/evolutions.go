@@ -0,0 +1,65 @@
+package api
+
+import (
+	"GO-Mongo/evolution"
+	"GO-Mongo/gql"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// evolutionHandler serves evolution chains. The evolutions collection spans
+// every region, so dex numbers are resolved across all of them.
+type evolutionHandler struct {
+	repo       repository.PokemonRepository
+	evolutions repository.EvolutionRepository
+	regions    []gql.Region
+}
+
+// EvolutionChain is the family tree of a Pokemon.
+type EvolutionChain struct {
+	DexNumber string          `json:"dex_number"`
+	Name      string          `json:"name"`
+	Chain     evolution.Stage `json:"chain" doc:"First stage of the family, with every branch"`
+}
+
+func (h *evolutionHandler) register(rt documented) {
+	rt.GET("/pokemon/:id/evolutions", &openapi.Operation{
+		OperationID: "getPokemonEvolutions",
+		Summary:     "Full evolution chain of a Pokemon, including branches",
+		Tags:        []string{"pokemon"},
+		Parameters:  []openapi.Parameter{openapi.PathParam("id", "National Dex number, e.g. 133", openapi.Integer())},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The evolution chain", rt.spec.SchemaOf(EvolutionChain{}), "")}),
+	}, h.getEvolutions)
+}
+
+func (h *evolutionHandler) getEvolutions(c *gin.Context) {
+	pokemon, ok := pokemonParam(c, h.repo)
+	if !ok {
+		return
+	}
+	evolutions, err := h.evolutions.Evolutions(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	chain := evolution.Chain(evolutions, pokemon.Name)
+	dex := map[string]string{}
+	for _, region := range h.regions {
+		result, err := region.Pokemon.Lookup(c.Request.Context(), chain.Names())
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for _, p := range result.Found {
+			dex[strings.ToLower(strings.TrimSpace(p.Name))] = p.DexNumber
+		}
+	}
+	chain.SetDexNumbers(dex)
+
+	renderer.Render(c, http.StatusOK, EvolutionChain{DexNumber: pokemon.DexNumber, Name: pokemon.Name, Chain: chain})
+}
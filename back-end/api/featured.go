@@ -0,0 +1,194 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/featured"
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes whose body changes while the dataset does not; the HTTP cache must
+// not derive their ETags from the dataset version.
+const (
+	randomRoute = "/api/pokemon/random"
+	dailyRoute  = "/api/pokemon/daily"
+)
+
+const dateLayout = "2006-01-02"
+
+// featuredHandler picks a random Pokemon or the Pokemon of the day from every
+// region.
+type featuredHandler struct {
+	regions []gql.Region
+}
+
+// FeaturedPokemon is a picked Pokemon and the region it comes from.
+type FeaturedPokemon struct {
+	Region   string    `json:"region"`
+	Date     string    `json:"date,omitempty" doc:"The day of the Pokemon of the day, YYYY-MM-DD"`
+	TimeZone string    `json:"time_zone,omitempty" doc:"The time zone the day is taken in"`
+	Pokemon  PokemonV2 `json:"pokemon"`
+}
+
+// regionalPokemon is a Pokemon of the pool with its region.
+type regionalPokemon struct {
+	region  string
+	pokemon models.Pokemon
+}
+
+func (h *featuredHandler) register(rt documented) {
+	tags := []string{"pokemon"}
+	names := make([]string, len(h.regions))
+	for i, r := range h.regions {
+		names[i] = r.Name
+	}
+	region := openapi.QueryParam("region", "Only Pokemon of this region (default: every region)", openapi.Enum(names...))
+
+	rt.GET("/pokemon/random", &openapi.Operation{
+		OperationID: "getRandomPokemon",
+		Summary:     "A random Pokemon, optionally of a type, legendary status or region",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("type", "Only Pokemon with this type in either slot", openapi.String()),
+			openapi.QueryParam("legendary", "Only legendary (true) or non-legendary (false) Pokemon", openapi.Boolean()),
+			region,
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("A random Pokemon; never cached", rt.spec.SchemaOf(FeaturedPokemon{}), "")}),
+	}, h.getRandom)
+
+	rt.GET("/pokemon/daily", &openapi.Operation{
+		OperationID: "getDailyPokemon",
+		Summary:     "The Pokemon of the day, the same for everyone on a given date",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("tz", "IANA time zone the date is taken in, e.g. Asia/Bangkok (default: UTC)", openapi.String()),
+			openapi.QueryParam("date", "Day to look up, YYYY-MM-DD (default: today in tz)", openapi.String()),
+			region,
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The Pokemon of the day; cacheable until the day ends", rt.spec.SchemaOf(FeaturedPokemon{}), "")}),
+	}, h.getDaily)
+}
+
+func (h *featuredHandler) getRandom(c *gin.Context) {
+	filter := repository.SearchFilter{Type: c.Query("type")}
+	switch legendary := c.Query("legendary"); legendary {
+	case "":
+	case "true", "false":
+		b := legendary == "true"
+		filter.Legendary = &b
+	default:
+		_ = c.Error(apierror.InvalidParam("legendary", "must be true or false"))
+		return
+	}
+	regions, ok := h.regionParam(c)
+	if !ok {
+		return
+	}
+
+	var pool []regionalPokemon
+	for _, region := range regions {
+		pokemons, err := region.Pokemon.Search(c.Request.Context(), filter)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for _, p := range pokemons {
+			pool = append(pool, regionalPokemon{region: region.Name, pokemon: p})
+		}
+	}
+	if len(pool) == 0 {
+		_ = c.Error(apierror.NotFound("No Pokemon matches the filters"))
+		return
+	}
+
+	pick := pool[rand.IntN(len(pool))]
+	c.Header("Cache-Control", "no-store")
+	renderer.Render(c, http.StatusOK, FeaturedPokemon{Region: pick.region, Pokemon: NewPokemonV2(pick.pokemon)})
+}
+
+func (h *featuredHandler) getDaily(c *gin.Context) {
+	tz := strings.TrimSpace(c.Query("tz"))
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		_ = c.Error(apierror.InvalidParam("tz", "must be an IANA time zone such as Asia/Bangkok"))
+		return
+	}
+	now := time.Now().In(loc)
+	day := now
+	if raw := c.Query("date"); raw != "" {
+		if day, err = time.ParseInLocation(dateLayout, raw, loc); err != nil {
+			_ = c.Error(apierror.InvalidParam("date", "must be a date in the form YYYY-MM-DD"))
+			return
+		}
+	}
+	regions, ok := h.regionParam(c)
+	if !ok {
+		return
+	}
+
+	var pool []regionalPokemon
+	for _, region := range regions {
+		pokemons, err := region.Pokemon.List(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for _, p := range pokemons {
+			pool = append(pool, regionalPokemon{region: region.Name, pokemon: p})
+		}
+	}
+	if len(pool) == 0 {
+		_ = c.Error(apierror.NotFound("No Pokemon to pick from"))
+		return
+	}
+	// The pick depends only on the date and this order, never on the order
+	// the repository returns documents in.
+	slices.SortStableFunc(pool, func(a, b regionalPokemon) int { return a.pokemon.Number() - b.pokemon.Number() })
+
+	pick := pool[featured.Index(len(pool), featured.Day(day))]
+	if featured.Day(day) == featured.Day(now) {
+		c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(featured.Until(now).Seconds())))
+	}
+	renderer.Render(c, http.StatusOK, FeaturedPokemon{
+		Region:   pick.region,
+		Date:     day.Format(dateLayout),
+		TimeZone: loc.String(),
+		Pokemon:  NewPokemonV2(pick.pokemon),
+	})
+}
+
+// regionParam selects the regions of the optional region parameter.
+func (h *featuredHandler) regionParam(c *gin.Context) ([]gql.Region, bool) {
+	return selectRegion(c, h.regions, "region", c.Query("region"))
+}
+
+// selectRegion returns the region called name, or every region when name is
+// empty. An unknown name is reported as an invalid field.
+func selectRegion(c *gin.Context, regions []gql.Region, field, name string) ([]gql.Region, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return regions, true
+	}
+	names := make([]string, len(regions))
+	for i, r := range regions {
+		if strings.EqualFold(r.Name, name) {
+			return []gql.Region{r}, true
+		}
+		names[i] = r.Name
+	}
+	_ = c.Error(apierror.InvalidParam(field, "must be one of "+strings.Join(names, ", ")))
+	return nil, false
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/gql"
+	"GO-Mongo/openapi"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// graphiqlHTML loads the GraphiQL playground pointed at /graphql.
+//
+//go:embed graphiql.html
+var graphiqlHTML []byte
+
+// maxGraphQLBody bounds the size of a POSTed GraphQL request.
+const maxGraphQLBody = 1 << 20
+
+type graphqlHandler struct {
+	service *gql.Service
+}
+
+func (h *graphqlHandler) register(rt documented) {
+	tags := []string{"graphql"}
+	result := openapi.JSON("GraphQL result; errors are reported in the body's errors array", &openapi.Schema{Type: "object"})
+
+	rt.GET("", &openapi.Operation{
+		OperationID: "graphqlQuery",
+		Summary:     "Run a GraphQL query passed in the query string",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			{Name: "query", In: "query", Required: true, Schema: openapi.String()},
+			openapi.QueryParam("operationName", "Operation to run when the document has several", openapi.String()),
+			openapi.QueryParam("variables", "JSON object of variable values", openapi.String()),
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: result}),
+	}, h.get)
+
+	rt.POST("", &openapi.Operation{
+		OperationID: "graphqlExecute",
+		Summary:     "Run a GraphQL request",
+		Description: "The schema can be explored with introspection or, in development, the playground at /graphiql.",
+		Tags:        tags,
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]openapi.MediaType{"application/json": {Schema: rt.spec.SchemaOf(gql.Request{})}},
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: result}),
+	}, h.post)
+}
+
+func (h *graphqlHandler) get(c *gin.Context) {
+	req := gql.Request{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if vars := c.Query("variables"); vars != "" {
+		if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+			_ = c.Error(apierror.InvalidParam("variables", "must be a JSON object"))
+			return
+		}
+	}
+	h.execute(c, req)
+}
+
+func (h *graphqlHandler) post(c *gin.Context) {
+	var req gql.Request
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxGraphQLBody)
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		_ = c.Error(apierror.InvalidInput("Request body must be a JSON object with a query"))
+		return
+	}
+	h.execute(c, req)
+}
+
+func (h *graphqlHandler) execute(c *gin.Context, req gql.Request) {
+	if req.Query == "" {
+		_ = c.Error(apierror.InvalidParam("query", "is required"))
+		return
+	}
+	c.JSON(http.StatusOK, h.service.Execute(c.Request.Context(), req))
+}
+
+func serveGraphiQL(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", graphiqlHTML)
+}
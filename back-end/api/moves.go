@@ -0,0 +1,224 @@
+package api
+
+import (
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// moveHandler serves moves and learnsets. Learnsets are keyed by dex number,
+// so Pokemon names are resolved across every region.
+type moveHandler struct {
+	moves   repository.MoveRepository
+	regions []gql.Region
+}
+
+// MoveLearner is a Pokemon that learns a given move in one game version.
+type MoveLearner struct {
+	DexNumber string `json:"dex_number"`
+	Name      string `json:"name" doc:"Empty for Pokemon outside the served datasets"`
+	Version   string `json:"version"`
+	models.LearnedMove
+}
+
+// MoveDetail is a move and the Pokemon that learn it.
+type MoveDetail struct {
+	models.Move
+	Learners []MoveLearner `json:"learners" doc:"By dex number, then version"`
+}
+
+// PokemonMove is a move of a learnset with the move's data in the
+// learnset's version.
+type PokemonMove struct {
+	models.LearnedMove
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Power    *int   `json:"power"`
+	Accuracy *int   `json:"accuracy"`
+	PP       int    `json:"pp"`
+}
+
+// VersionMoves is a Pokemon's learnset in one game version.
+type VersionMoves struct {
+	Version string        `json:"version"`
+	Moves   []PokemonMove `json:"moves"`
+}
+
+// PokemonMoves lists the moves a Pokemon learns, by game version.
+type PokemonMoves struct {
+	DexNumber string         `json:"dex_number"`
+	Name      string         `json:"name"`
+	Learnsets []VersionMoves `json:"learnsets"`
+}
+
+func (h *moveHandler) register(rt documented) {
+	tags := []string{"moves"}
+	version := openapi.QueryParam("version", "Game version group, e.g. red-blue (default: all)", openapi.String())
+
+	rt.GET("/moves", &openapi.Operation{
+		OperationID: "listMoves",
+		Summary:     "Every move with its type, category, power, accuracy, PP and effect",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("type", "Only moves of this type, e.g. Fire", openapi.String()),
+			openapi.QueryParam("category", "Only moves of this category: physical, special or status", openapi.String()),
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Moves sorted by name", rt.spec.SchemaOf([]models.Move{}), "")}),
+	}, h.listMoves)
+
+	rt.GET("/moves/:name", &openapi.Operation{
+		OperationID: "getMove",
+		Summary:     "A move and every Pokemon that learns it",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("name", "Move name, case-insensitive, e.g. Thunderbolt", openapi.String()),
+			openapi.QueryParam("version", "Game version group, e.g. red-blue: only its learners, and the move's values in that version (default: all, with the latest values)", openapi.String()),
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The move", rt.spec.SchemaOf(MoveDetail{}), "")}),
+	}, h.getMove)
+
+	rt.GET("/pokemon/:id/moves", &openapi.Operation{
+		OperationID: "getPokemonMoves",
+		Summary:     "Moves a Pokemon learns by level-up, TM/HM, breeding or tutor",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("id", "National Dex number, e.g. 25", openapi.Integer()),
+			version,
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The learnsets, by version", rt.spec.SchemaOf(PokemonMoves{}), "")}),
+	}, h.getPokemonMoves)
+}
+
+func (h *moveHandler) listMoves(c *gin.Context) {
+	moves, err := h.moves.Moves(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	moveType, category := strings.TrimSpace(c.Query("type")), strings.TrimSpace(c.Query("category"))
+	out := []models.Move{}
+	for _, m := range moves {
+		if (moveType == "" || strings.EqualFold(m.Type, moveType)) && (category == "" || strings.EqualFold(m.Category, category)) {
+			out = append(out, m)
+		}
+	}
+	renderer.Render(c, http.StatusOK, out)
+}
+
+func (h *moveHandler) getMove(c *gin.Context) {
+	name := c.Param("name")
+	move, err := h.moves.GetMove(c.Request.Context(), name)
+	if err != nil {
+		_ = c.Error(notFoundAs(err, "Move "+strconv.Quote(name)+" not found"))
+		return
+	}
+	learnsets, err := h.moves.Learners(c.Request.Context(), move.Name)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	version := strings.TrimSpace(c.Query("version"))
+	learnsets = inVersion(learnsets, version)
+	if version != "" {
+		move = move.InGeneration(models.VersionGroups[strings.ToLower(version)])
+	}
+
+	dex := make([]string, 0, len(learnsets))
+	for _, l := range learnsets {
+		dex = append(dex, l.DexNumber)
+	}
+	names, ok := h.names(c, dex)
+	if !ok {
+		return
+	}
+
+	learners := []MoveLearner{}
+	for _, l := range learnsets {
+		for _, lm := range l.Moves {
+			if strings.EqualFold(strings.TrimSpace(lm.Move), move.Name) {
+				learners = append(learners, MoveLearner{DexNumber: l.DexNumber, Name: names[l.DexNumber], Version: l.Version, LearnedMove: lm})
+			}
+		}
+	}
+	renderer.Render(c, http.StatusOK, MoveDetail{Move: move, Learners: learners})
+}
+
+func (h *moveHandler) getPokemonMoves(c *gin.Context) {
+	pokemon, ok := regionPokemonParam(c, h.regions)
+	if !ok {
+		return
+	}
+	learnsets, err := h.moves.Learnsets(c.Request.Context(), pokemon.Number())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	moves, err := h.moves.Moves(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	byName := make(map[string]models.Move, len(moves))
+	for _, m := range moves {
+		byName[strings.ToLower(m.Name)] = m
+	}
+
+	out := PokemonMoves{DexNumber: pokemon.DexNumber, Name: pokemon.Name, Learnsets: []VersionMoves{}}
+	for _, l := range inVersion(learnsets, c.Query("version")) {
+		vm := VersionMoves{Version: l.Version, Moves: make([]PokemonMove, 0, len(l.Moves))}
+		gen := models.VersionGroups[l.Version]
+		for _, lm := range l.Moves {
+			m := byName[strings.ToLower(strings.TrimSpace(lm.Move))].InGeneration(gen)
+			vm.Moves = append(vm.Moves, PokemonMove{
+				LearnedMove: lm,
+				Type:        m.Type,
+				Category:    m.Category,
+				Power:       m.Power,
+				Accuracy:    m.Accuracy,
+				PP:          m.PP,
+			})
+		}
+		out.Learnsets = append(out.Learnsets, vm)
+	}
+	renderer.Render(c, http.StatusOK, out)
+}
+
+// names maps dex numbers to Pokemon names across every region.
+func (h *moveHandler) names(c *gin.Context, dex []string) (map[string]string, bool) {
+	names := map[string]string{}
+	if len(dex) == 0 {
+		return names, true
+	}
+	for _, region := range h.regions {
+		result, err := region.Pokemon.Lookup(c.Request.Context(), dex)
+		if err != nil {
+			_ = c.Error(err)
+			return nil, false
+		}
+		for _, p := range result.Found {
+			names[p.DexNumber] = p.Name
+		}
+	}
+	return names, true
+}
+
+// inVersion keeps the learnsets of version; an empty version keeps all.
+func inVersion(learnsets []models.Learnset, version string) []models.Learnset {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return learnsets
+	}
+	out := []models.Learnset{}
+	for _, l := range learnsets {
+		if strings.EqualFold(l.Version, version) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func intPtr(n int) *int { return &n }
+
+// testMoves is a small moves repository with Kanto and Johto learnsets
+// using every learn method.
+func testMoves() *repository.MemoryMoves {
+	return repository.NewMemoryMoves(
+		[]models.Move{
+			{Name: "Tackle", Type: "Normal", Category: models.CategoryPhysical, Power: intPtr(40), Accuracy: intPtr(100), PP: 35},
+			{Name: "Razor Leaf", Type: "Grass", Category: models.CategoryPhysical, Power: intPtr(55), Accuracy: intPtr(95), PP: 25},
+			{Name: "Vine Whip", Type: "Grass", Category: models.CategoryPhysical, Power: intPtr(45), Accuracy: intPtr(100), PP: 25},
+			{Name: "Thunder Shock", Type: "Electric", Category: models.CategorySpecial, Power: intPtr(40), Accuracy: intPtr(100), PP: 30},
+			{Name: "Thunderbolt", Type: "Electric", Category: models.CategorySpecial, Power: intPtr(90), Accuracy: intPtr(100), PP: 15,
+				Past: []models.PastValues{{Generation: 5, Power: intPtr(95)}}},
+			{Name: "Thunder", Type: "Electric", Category: models.CategorySpecial, Power: intPtr(110), Accuracy: intPtr(70), PP: 10},
+			{Name: "Reversal", Type: "Fighting", Category: models.CategoryPhysical, Accuracy: intPtr(100), PP: 15},
+		},
+		[]models.Learnset{
+			{DexNumber: "#0001", Version: "red-blue", Moves: []models.LearnedMove{{Move: "Tackle", Method: models.LearnLevelUp, Level: 1}}},
+			{DexNumber: "#0025", Version: "crystal", Moves: []models.LearnedMove{
+				{Move: "Thunder Shock", Method: models.LearnLevelUp, Level: 1},
+				{Move: "Thunder", Method: models.LearnMachine, Machine: "TM25"},
+				{Move: "Reversal", Method: models.LearnEgg},
+				{Move: "Thunderbolt", Method: models.LearnTutor},
+			}},
+			{DexNumber: "#0025", Version: "x-y", Moves: []models.LearnedMove{
+				{Move: "Thunder Shock", Method: models.LearnLevelUp, Level: 1},
+				{Move: "Thunderbolt", Method: models.LearnMachine, Machine: "TM24"},
+			}},
+			{DexNumber: "#0152", Version: "gold-silver", Moves: []models.LearnedMove{
+				{Move: "Tackle", Method: models.LearnLevelUp, Level: 1},
+				{Move: "Razor Leaf", Method: models.LearnLevelUp, Level: 8},
+				{Move: "Vine Whip", Method: models.LearnEgg},
+			}},
+		},
+	)
+}
+
+func TestPokemonMovesAcrossRegions(t *testing.T) {
+	r := newTestRouter(t, func(o *Options) { o.Moves = testMoves() })
+	w := serve(r, http.MethodGet, "/api/pokemon/152/moves", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got PokemonMoves
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Chikorita" || len(got.Learnsets) != 1 || len(got.Learnsets[0].Moves) != 3 {
+		t.Errorf("got %+v, want Chikorita's gold-silver learnset", got)
+	}
+}
+
+func TestMovesInTheLearnsetsGeneration(t *testing.T) {
+	r := newTestRouter(t, func(o *Options) { o.Moves = repository.NewMemoryMoves(fixture.Moves(t)) })
+	w := serve(r, http.MethodGet, "/api/pokemon/7/moves?version=red-blue", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got PokemonMoves
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Learnsets) != 1 {
+		t.Fatalf("learnsets = %+v, want red-blue only", got.Learnsets)
+	}
+	moves := map[string]PokemonMove{}
+	for _, m := range got.Learnsets[0].Moves {
+		moves[m.Move] = m
+	}
+	tests := []struct {
+		move, typ, category string
+		power, accuracy     int
+	}{
+		{"Bite", "Normal", models.CategoryPhysical, 60, 100},
+		{"Tackle", "Normal", models.CategoryPhysical, 35, 95},
+		{"Bubble", "Water", models.CategorySpecial, 20, 100},
+		{"Skull Bash", "Normal", models.CategoryPhysical, 100, 100},
+	}
+	for _, tt := range tests {
+		m, ok := moves[tt.move]
+		if !ok {
+			t.Errorf("Squirtle does not learn %s", tt.move)
+			continue
+		}
+		if m.Type != tt.typ || m.Category != tt.category || m.Power == nil || *m.Power != tt.power || m.Accuracy == nil || *m.Accuracy != tt.accuracy {
+			t.Errorf("%s = %s %s %v/%v, want %s %s %d/%d", tt.move, m.Type, m.Category, deref(m.Power), deref(m.Accuracy), tt.typ, tt.category, tt.power, tt.accuracy)
+		}
+	}
+
+	for version, want := range map[string]string{"": "Dark", "red-blue": "Normal"} {
+		w := serve(r, http.MethodGet, "/api/moves/bite?version="+version, "")
+		var move MoveDetail
+		if err := json.Unmarshal(w.Body.Bytes(), &move); err != nil {
+			t.Fatal(err)
+		}
+		if move.Type != want || (version == "") != (len(move.Past) > 0) {
+			t.Errorf("Bite in %q = %s with past %v, want %s", version, move.Type, move.Past, want)
+		}
+	}
+}
+
+func deref(p *int) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func TestPokemonMoves(t *testing.T) {
+	r := newTestRouter(t, func(o *Options) { o.Moves = testMoves() })
+	get := func(target string) PokemonMoves {
+		t.Helper()
+		w := serve(r, http.MethodGet, target, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d: %s", target, w.Code, w.Body)
+		}
+		var got PokemonMoves
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	got := get("/api/pokemon/25/moves")
+	if len(got.Learnsets) != 2 || got.Learnsets[0].Version != "crystal" || got.Learnsets[1].Version != "x-y" {
+		t.Fatalf("learnsets = %+v, want crystal and x-y", got.Learnsets)
+	}
+	want := []PokemonMove{
+		{LearnedMove: models.LearnedMove{Move: "Thunder Shock", Method: models.LearnLevelUp, Level: 1}, Type: "Electric", Category: models.CategorySpecial, Power: intPtr(40), Accuracy: intPtr(100), PP: 30},
+		{LearnedMove: models.LearnedMove{Move: "Thunder", Method: models.LearnMachine, Machine: "TM25"}, Type: "Electric", Category: models.CategorySpecial, Power: intPtr(110), Accuracy: intPtr(70), PP: 10},
+		// Generation 2 decided the category by type.
+		{LearnedMove: models.LearnedMove{Move: "Reversal", Method: models.LearnEgg}, Type: "Fighting", Category: models.CategoryPhysical, Accuracy: intPtr(100), PP: 15},
+		{LearnedMove: models.LearnedMove{Move: "Thunderbolt", Method: models.LearnTutor}, Type: "Electric", Category: models.CategorySpecial, Power: intPtr(95), Accuracy: intPtr(100), PP: 15},
+	}
+	if !reflect.DeepEqual(got.Learnsets[0].Moves, want) {
+		t.Errorf("crystal moves = %s, want %s", jsonString(got.Learnsets[0].Moves), jsonString(want))
+	}
+	if bolt := got.Learnsets[1].Moves[1]; bolt.Machine != "TM24" || *bolt.Power != 90 {
+		t.Errorf("x-y Thunderbolt = %s, want TM24 with 90 power", jsonString(bolt))
+	}
+
+	if got := get("/api/pokemon/25/moves?version=X-Y"); len(got.Learnsets) != 1 || got.Learnsets[0].Version != "x-y" {
+		t.Errorf("version=X-Y learnsets = %+v, want x-y only", got.Learnsets)
+	}
+	if got := get("/api/pokemon/4/moves"); got.Name != "Charmander" || got.Learnsets == nil || len(got.Learnsets) != 0 {
+		t.Errorf("Charmander = %+v, want an empty list of learnsets", got)
+	}
+	if w := serve(r, http.MethodGet, "/api/pokemon/252/moves", ""); w.Code != http.StatusNotFound {
+		t.Errorf("#252: status %d, want 404", w.Code)
+	}
+}
+
+func TestGetMove(t *testing.T) {
+	r := newTestRouter(t, func(o *Options) { o.Moves = testMoves() })
+	tests := []struct {
+		target   string
+		power    int
+		learners []string
+	}{
+		{"/api/moves/THUNDERBOLT", 90, []string{"#0025 Pikachu crystal tutor", "#0025 Pikachu x-y machine"}},
+		{"/api/moves/thunderbolt?version=crystal", 95, []string{"#0025 Pikachu crystal tutor"}},
+		{"/api/moves/vine%20whip", 45, []string{"#0152 Chikorita gold-silver egg"}},
+		{"/api/moves/thunderbolt?version=red-blue", 95, nil},
+	}
+	for _, tt := range tests {
+		w := serve(r, http.MethodGet, tt.target, "")
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s: status %d: %s", tt.target, w.Code, w.Body)
+			continue
+		}
+		var got MoveDetail
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		var learners []string
+		for _, l := range got.Learners {
+			learners = append(learners, l.DexNumber+" "+l.Name+" "+l.Version+" "+l.Method)
+		}
+		if *got.Power != tt.power || !slices.Equal(learners, tt.learners) || got.Learners == nil {
+			t.Errorf("GET %s = %d power, learners %v, want %d and %v", tt.target, *got.Power, learners, tt.power, tt.learners)
+		}
+	}
+	if w := serve(r, http.MethodGet, "/api/moves/surf", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown move: status %d, want 404", w.Code)
+	}
+}
+
+func jsonString(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
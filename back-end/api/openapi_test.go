@@ -0,0 +1,87 @@
+package api
+
+import (
+	"GO-Mongo/openapi"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// undocumented lists routes that intentionally have no operation in the
+// OpenAPI document.
+var undocumented = map[string]bool{
+	"GET /api/openapi.json": true,
+	"GET /api/docs":         true,
+	"GET /graphiql":         true,
+}
+
+func fetchSpec(t *testing.T, r http.Handler) openapi.Document {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /api/openapi.json: status %d", w.Code)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding OpenAPI document: %v", err)
+	}
+	return doc
+}
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, err := NewRouter(Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := fetchSpec(t, r)
+
+	routed := map[string]bool{}
+	for _, route := range r.Routes() {
+		key := route.Method + " " + route.Path
+		routed[route.Method+" "+openapi.PathFromGin(route.Path)] = true
+		if undocumented[key] {
+			continue
+		}
+		if doc.Operation(route.Method, route.Path) == nil {
+			t.Errorf("route %s is not documented in the OpenAPI document", key)
+		}
+	}
+
+	operationIDs := map[string]string{}
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			key := method + " " + path
+			if !routed[strings.ToUpper(method)+" "+path] {
+				t.Errorf("documented operation %s has no route", key)
+			}
+			if op.OperationID == "" {
+				t.Errorf("%s has no operationId", key)
+			}
+			if prev, dup := operationIDs[op.OperationID]; dup {
+				t.Errorf("operationId %q used by both %s and %s", op.OperationID, prev, key)
+			}
+			operationIDs[op.OperationID] = key
+		}
+	}
+}
+
+func TestSwaggerUIIsServed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, err := NewRouter(Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Fatalf("GET /api/docs: status %d, content type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+}
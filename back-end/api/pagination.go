@@ -0,0 +1,70 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/openapi"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TotalCountHeader carries the number of items before pagination.
+const TotalCountHeader = "X-Total-Count"
+
+const maxPageLimit = 1000
+
+// pageParams documents the optional limit/offset parameters of list routes.
+func pageParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("limit", "Maximum number of items to return (default: all, max 1000)", openapi.Integer()),
+		openapi.QueryParam("offset", "Number of items to skip", openapi.Integer()),
+	}
+}
+
+// paginate applies the optional limit and offset query parameters to items.
+// The response body stays a plain array; the total is reported in
+// X-Total-Count and the next page in a Link header. It records an error on c
+// and returns false when the parameters are invalid.
+func paginate[T any](c *gin.Context, items []T) ([]T, bool) {
+	limit, ok := intQuery(c, "limit", 1, maxPageLimit)
+	if !ok {
+		return nil, false
+	}
+	offset, ok := intQuery(c, "offset", 0, -1)
+	if !ok {
+		return nil, false
+	}
+
+	total := len(items)
+	c.Header(TotalCountHeader, strconv.Itoa(total))
+	if offset >= total {
+		return []T{}, true
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+		next := c.Request.URL.Query()
+		next.Set("offset", strconv.Itoa(offset+limit))
+		c.Writer.Header().Add("Link", "<"+c.Request.URL.Path+"?"+next.Encode()+`>; rel="next"`)
+	}
+	return items, true
+}
+
+// intQuery parses an optional integer query parameter within [min, max]; a
+// negative max means unbounded. A missing parameter yields 0.
+func intQuery(c *gin.Context, name string, min, max int) (int, bool) {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < min || (max >= 0 && n > max) {
+		reason := "must be an integer >= " + strconv.Itoa(min)
+		if max >= 0 {
+			reason += " and <= " + strconv.Itoa(max)
+		}
+		_ = c.Error(apierror.InvalidParam(name, reason))
+		return 0, false
+	}
+	return n, true
+}
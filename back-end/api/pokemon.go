@@ -0,0 +1,274 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pokemonHandler serves the Pokemon routes of one API version; the version
+// decides how Pokemon are serialized.
+type pokemonHandler struct {
+	repo    repository.PokemonRepository
+	version apiVersion
+}
+
+func (h *pokemonHandler) register(rt documented) {
+	pokemonList := &openapi.Schema{Type: "array", Items: rt.spec.SchemaOf(h.version.model)}
+	pokemon := rt.spec.SchemaOf(h.version.model)
+	tags := []string{"pokemon"}
+	rt = rt.versioned(h.version)
+
+	rt.GET("/pokemon", &openapi.Operation{
+		OperationID: "listPokemon",
+		Summary:     "List every Pokemon",
+		Tags:        tags,
+		Parameters:  pageParams(),
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("All Pokemon", pokemonList, "pokedex.v1.PokemonList")}),
+	}, h.getAllPokemon)
+
+	rt.GET("/pokemon/:id", &openapi.Operation{
+		OperationID: "getPokemon",
+		Summary:     "Get a Pokemon by National Dex number",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{openapi.PathParam("id", "National Dex number, e.g. 25", openapi.Integer())},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The Pokemon", pokemon, "pokedex.v1.Pokemon")}),
+	}, h.getPokemonByID)
+
+	rt.GET("/pokemon/name/:name", &openapi.Operation{
+		OperationID: "getPokemonByName",
+		Summary:     "Get a Pokemon by name (case-insensitive)",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{openapi.PathParam("name", "Pokemon name", openapi.String())},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The Pokemon", pokemon, "pokedex.v1.Pokemon")}),
+	}, h.getPokemonByName)
+
+	batch := negotiated("Found Pokemon in request order and the identifiers that matched nothing", batchSchema(rt.spec, h.version.model), "")
+	ids := openapi.QueryParam("ids", "Comma-separated dex numbers and names, e.g. 1,4,pikachu (at most 100)", openapi.String())
+	ids.Required = true
+	rt.GET("/pokemon/batch", &openapi.Operation{
+		OperationID: "getPokemonBatch",
+		Summary:     "Get several Pokemon by dex number or name",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{ids},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: batch}),
+	}, h.getBatch)
+
+	rt.POST("/pokemon/batch", &openapi.Operation{
+		OperationID: "postPokemonBatch",
+		Summary:     "Get several Pokemon by dex number or name",
+		Tags:        tags,
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]openapi.MediaType{"application/json": {Schema: rt.spec.SchemaOf(BatchRequest{})}},
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: batch}),
+	}, h.postBatch)
+
+	rt.GET("/pokemon/search", &openapi.Operation{
+		OperationID: "searchPokemon",
+		Summary:     "Search Pokemon by name, type and legendary status",
+		Tags:        tags,
+		Parameters: append([]openapi.Parameter{
+			openapi.QueryParam("q", "Substring of the name or dex number", openapi.String()),
+			openapi.QueryParam("type", "Type in either slot, e.g. Fire", openapi.String()),
+			openapi.QueryParam("legendary", "Legendary status", openapi.Boolean()),
+		}, pageParams()...),
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Matching Pokemon", pokemonList, "pokedex.v1.PokemonList")}),
+	}, h.searchPokemon)
+
+	rt.GET("/pokemon/types", &openapi.Operation{
+		OperationID: "listTypes",
+		Summary:     "List the types present in the dataset",
+		Tags:        tags,
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Type names", &openapi.Schema{Type: "array", Items: openapi.String()}, "")}),
+	}, h.getAvailableTypes)
+
+	rt.GET("/pokemon/legendary", &openapi.Operation{
+		OperationID: "listLegendaryPokemon",
+		Summary:     "List legendary Pokemon",
+		Tags:        tags,
+		Parameters:  pageParams(),
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Legendary Pokemon", pokemonList, "pokedex.v1.PokemonList")}),
+	}, h.getLegendaryPokemon)
+
+	rt.GET("/pokemon/stats", &openapi.Operation{
+		OperationID: "getStatsSummary",
+		Summary:     "Summary counts over the dataset",
+		Tags:        tags,
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Summary", rt.spec.SchemaOf(repository.StatsSummary{}), "pokedex.v1.GetStatsResponse")}),
+	}, h.getStatsSummary)
+}
+
+func (h *pokemonHandler) getAllPokemon(c *gin.Context) {
+	pokemons, err := h.repo.List(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	pokemons, ok := paginate(c, pokemons)
+	if !ok {
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, h.version.pokemonList(pokemons))
+}
+
+func (h *pokemonHandler) getPokemonByID(c *gin.Context) {
+	pokemon, ok := pokemonParam(c, h.repo)
+	if !ok {
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, h.version.pokemon(pokemon))
+}
+
+func (h *pokemonHandler) getPokemonByName(c *gin.Context) {
+	name := c.Param("name")
+
+	pokemon, err := h.repo.GetByName(c.Request.Context(), name)
+	if err != nil {
+		_ = c.Error(notFoundAs(err, "Pokemon named "+strconv.Quote(name)+" not found"))
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, h.version.pokemon(pokemon))
+}
+
+func (h *pokemonHandler) searchPokemon(c *gin.Context) {
+	filter := repository.SearchFilter{
+		Query: c.Query("q"),
+		Type:  c.Query("type"),
+	}
+
+	// Filter by legendary status
+	switch legendary := c.Query("legendary"); legendary {
+	case "":
+	case "true", "false":
+		b := legendary == "true"
+		filter.Legendary = &b
+	default:
+		_ = c.Error(apierror.InvalidParam("legendary", "must be true or false"))
+		return
+	}
+
+	pokemons, err := h.repo.Search(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	pokemons, ok := paginate(c, pokemons)
+	if !ok {
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, h.version.pokemonList(pokemons))
+}
+
+func (h *pokemonHandler) getAvailableTypes(c *gin.Context) {
+	types, err := h.repo.Types(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, types)
+}
+
+func (h *pokemonHandler) getLegendaryPokemon(c *gin.Context) {
+	pokemons, err := h.repo.Legendary(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	pokemons, ok := paginate(c, pokemons)
+	if !ok {
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, h.version.pokemonList(pokemons))
+}
+
+func (h *pokemonHandler) getStatsSummary(c *gin.Context) {
+	summary, err := h.repo.Stats(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, summary)
+}
+
+// pokemonParam loads the Pokemon named by the :id path parameter. It records
+// an error on c and returns false when the id is invalid or unknown.
+func pokemonParam(c *gin.Context, repo repository.PokemonRepository) (models.Pokemon, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		_ = c.Error(apierror.InvalidParam("id", "must be a positive dex number"))
+		return models.Pokemon{}, false
+	}
+
+	pokemon, err := repo.GetByDexNumber(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(notFoundAs(err, "Pokemon #"+idStr+" not found"))
+		return models.Pokemon{}, false
+	}
+	return pokemon, true
+}
+
+// regionPokemonParam resolves the :id path parameter to the Pokemon with
+// that dex number in whichever region has it.
+func regionPokemonParam(c *gin.Context, regions []gql.Region) (models.Pokemon, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		_ = c.Error(apierror.InvalidParam("id", "must be a positive dex number"))
+		return models.Pokemon{}, false
+	}
+
+	pokemon, err := findPokemon(c.Request.Context(), regions, strconv.Itoa(id))
+	if err != nil {
+		_ = c.Error(notFoundAs(err, "Pokemon #"+idStr+" not found"))
+		return models.Pokemon{}, false
+	}
+	return pokemon, true
+}
+
+// findPokemon returns the Pokemon identified by id, a dex number or a name,
+// from the first region that has it, or repository.ErrNotFound.
+func findPokemon(ctx context.Context, regions []gql.Region, id string) (models.Pokemon, error) {
+	for _, region := range regions {
+		result, err := region.Pokemon.Lookup(ctx, []string{id})
+		if err != nil {
+			return models.Pokemon{}, err
+		}
+		if len(result.Found) > 0 {
+			return result.Found[0], nil
+		}
+	}
+	return models.Pokemon{}, fmt.Errorf("pokemon %q: %w", id, repository.ErrNotFound)
+}
+
+// notFoundAs replaces the generic message of a repository not-found error
+// with one naming the requested resource.
+func notFoundAs(err error, message string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		e := apierror.NotFound(message)
+		e.Err = err
+		return e
+	}
+	return err
+}
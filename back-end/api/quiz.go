@@ -0,0 +1,242 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/gql"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/quiz"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// quizSessionRoute changes with every answer while the dataset does not.
+const quizSessionRoute = "/api/quiz/sessions/:id"
+
+// Quiz sessions live in memory: at most quizSessions at a time, each for
+// quizTTL after it was started or last answered.
+const (
+	quizSessions = 10000
+	quizTTL      = 30 * time.Minute
+)
+
+// quizHandler runs "Who's that Pokemon?" quizzes over every region.
+type quizHandler struct {
+	regions []gql.Region
+	store   *quiz.Store
+
+	mu    sync.Mutex
+	pools map[string]quizPool // by region, "" for every region
+}
+
+// quizPool is a question pool and the dataset versions it was built from.
+// Sessions share it; a session started before a reimport keeps drawing from
+// the pool it started with.
+type quizPool struct {
+	pool    *quiz.Pool
+	version string
+}
+
+// QuizRequest is the body of POST /quiz/sessions. Every field is optional.
+type QuizRequest struct {
+	Difficulty string   `json:"difficulty" doc:"easy, normal or hard (default: normal)"`
+	Kinds      []string `json:"kinds" doc:"Question kinds to draw from: bio, stats and higher_lower (default: all)"`
+	Region     string   `json:"region" doc:"Only Pokemon of this region (default: every region)"`
+	Length     int      `json:"length" doc:"Number of questions, up to 50 (default: no limit)"`
+}
+
+// QuizAnswer is the body of POST /quiz/sessions/{id}/answers.
+type QuizAnswer struct {
+	Question int    `json:"question" doc:"Number of the question answered, which must be the current one"`
+	Answer   string `json:"answer" doc:"One of the question's choices, case-insensitive"`
+}
+
+func (h *quizHandler) register(rt documented) {
+	tags := []string{"quiz"}
+	id := openapi.PathParam("id", "Session id, as returned when the session was started", openapi.String())
+
+	rt.POST("/quiz/sessions", &openapi.Operation{
+		OperationID: "startQuiz",
+		Summary:     "Start a quiz session and get its first question",
+		Description: "Questions ask for a Pokemon from its bio with the names redacted or from its types and base stats, " +
+			"or which of two Pokemon has the higher base stat. Harder difficulties offer more and closer choices, " +
+			"show less of the bio and its types, and score more per correct answer; every answer in a streak adds a bonus point, up to 5. " +
+			"Sessions are kept in memory for 30 minutes after the last answer.",
+		Tags: tags,
+		RequestBody: &openapi.RequestBody{
+			Content: map[string]openapi.MediaType{"application/json": {Schema: rt.spec.SchemaOf(QuizRequest{})}},
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusCreated: negotiated("The new session", rt.spec.SchemaOf(quiz.State{}), "")}),
+	}, h.start)
+
+	rt.GET("/quiz/sessions/:id", &openapi.Operation{
+		OperationID: "getQuiz",
+		Summary:     "A quiz session's score, streak and current question",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{id},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The session; never cached", rt.spec.SchemaOf(quiz.State{}), "")}),
+	}, h.get)
+
+	rt.POST("/quiz/sessions/:id/answers", &openapi.Operation{
+		OperationID: "answerQuiz",
+		Summary:     "Answer the current question of a quiz session and get the next one",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]openapi.MediaType{"application/json": {Schema: rt.spec.SchemaOf(QuizAnswer{})}},
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Whether the answer was right, and the session after it", rt.spec.SchemaOf(quiz.Outcome{}), "")}),
+	}, h.answer)
+}
+
+func (h *quizHandler) start(c *gin.Context) {
+	var req QuizRequest
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBody)
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apierror.InvalidInput("Request body must be a JSON object with optional difficulty, kinds, region and length"))
+		return
+	}
+
+	opts := quiz.Options{Length: req.Length}
+	var err error
+	if opts.Difficulty, err = quiz.ParseDifficulty(req.Difficulty); err != nil {
+		_ = c.Error(apierror.InvalidParam("difficulty", "must be easy, normal or hard"))
+		return
+	}
+	for _, k := range req.Kinds {
+		kind, err := quiz.ParseKind(k)
+		if err != nil {
+			_ = c.Error(apierror.InvalidParam("kinds", strconv.Quote(k)+" is not bio, stats or higher_lower"))
+			return
+		}
+		opts.Kinds = append(opts.Kinds, kind)
+	}
+	if req.Length < 0 || req.Length > quiz.MaxLength {
+		_ = c.Error(apierror.InvalidParam("length", "must be between 0 and "+strconv.Itoa(quiz.MaxLength)))
+		return
+	}
+	regions, ok := selectRegion(c, h.regions, "region", req.Region)
+	if !ok {
+		return
+	}
+
+	key := ""
+	if len(regions) == 1 && len(h.regions) > 1 {
+		key = regions[0].Name
+	}
+	pool, ok := h.pool(c, key, regions)
+	if !ok {
+		return
+	}
+	state, err := h.store.Start(pool, opts)
+	if errors.Is(err, quiz.ErrPoolTooSmall) {
+		_ = c.Error(apierror.InvalidInput("Not enough Pokemon to ask " + strings.Join(kindNames(opts.Kinds), ", ") + " questions"))
+		return
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Header("Location", "/api/quiz/sessions/"+state.ID)
+	c.Header("Cache-Control", "no-store")
+	renderer.Render(c, http.StatusCreated, state)
+}
+
+func (h *quizHandler) get(c *gin.Context) {
+	state, err := h.store.Get(c.Param("id"))
+	if err != nil {
+		_ = c.Error(quizError(c, err))
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	renderer.Render(c, http.StatusOK, state)
+}
+
+func (h *quizHandler) answer(c *gin.Context) {
+	var req QuizAnswer
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBody)
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		_ = c.Error(apierror.InvalidInput("Request body must be a JSON object with question and answer"))
+		return
+	}
+	if req.Question < 1 {
+		_ = c.Error(apierror.InvalidParam("question", "must be the number of the current question"))
+		return
+	}
+	if strings.TrimSpace(req.Answer) == "" {
+		_ = c.Error(apierror.InvalidParam("answer", "is required"))
+		return
+	}
+
+	out, err := h.store.Answer(c.Param("id"), req.Question, req.Answer)
+	if err != nil {
+		_ = c.Error(quizError(c, err))
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	renderer.Render(c, http.StatusOK, out)
+}
+
+// pool returns the question pool of regions, stored under key, rebuilding
+// it when their datasets have changed.
+func (h *quizHandler) pool(c *gin.Context, key string, regions []gql.Region) (*quiz.Pool, bool) {
+	version, ok := datasetVersion(c, regions)
+	if !ok {
+		return nil, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if p, ok := h.pools[key]; ok && p.version == version {
+		return p.pool, true
+	}
+	var pokemons []models.Pokemon
+	for _, region := range regions {
+		list, err := region.Pokemon.List(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return nil, false
+		}
+		pokemons = append(pokemons, list...)
+	}
+	if h.pools == nil {
+		h.pools = map[string]quizPool{}
+	}
+	pool := quiz.NewPool(pokemons)
+	h.pools[key] = quizPool{pool: pool, version: version}
+	return pool, true
+}
+
+// quizError maps the session errors of the quiz package to API errors.
+func quizError(c *gin.Context, err error) error {
+	switch {
+	case errors.Is(err, quiz.ErrNotFound):
+		return apierror.NotFound("Quiz session " + strconv.Quote(c.Param("id")) + " not found or expired")
+	case errors.Is(err, quiz.ErrFinished):
+		return apierror.InvalidInput("The quiz session is finished; start a new one")
+	case errors.Is(err, quiz.ErrNotCurrent):
+		return apierror.InvalidParam("question", "must be the number of the current question")
+	}
+	return err
+}
+
+// kindNames returns the names of kinds, or of every kind when it is empty.
+func kindNames(kinds []quiz.Kind) []string {
+	if len(kinds) == 0 {
+		kinds = quiz.Kinds
+	}
+	out := make([]string, len(kinds))
+	for i, k := range kinds {
+		out[i] = string(k)
+	}
+	return out
+}
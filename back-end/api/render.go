@@ -0,0 +1,53 @@
+package api
+
+import (
+	pokedexv1 "GO-Mongo/gen/pokedex/v1"
+	"GO-Mongo/grpcapi"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/render"
+	"GO-Mongo/repository"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// renderer writes API results as JSON, MessagePack, CBOR or protobuf
+// according to the Accept header. The protobuf messages are the ones the
+// gRPC service uses.
+var renderer = render.Renderer{ToProto: toProto}
+
+func toProto(v any) (proto.Message, bool) {
+	switch v := v.(type) {
+	case models.Pokemon:
+		return grpcapi.ToProto(v), true
+	case []models.Pokemon:
+		return &pokedexv1.PokemonList{Pokemon: grpcapi.ToProtoList(v)}, true
+	case PokemonV2:
+		return grpcapi.ToProto(v.source), true
+	case []PokemonV2:
+		list := &pokedexv1.PokemonList{Pokemon: make([]*pokedexv1.Pokemon, len(v))}
+		for i, p := range v {
+			list.Pokemon[i] = grpcapi.ToProto(p.source)
+		}
+		return list, true
+	case repository.StatsSummary:
+		return grpcapi.StatsToProto(v), true
+	}
+	return nil, false
+}
+
+// negotiated documents a response available in every format the renderer
+// produces. protoMessage names the protobuf message, if there is one.
+func negotiated(description string, schema *openapi.Schema, protoMessage string) openapi.Response {
+	r := openapi.JSON(description, schema)
+	r.Content[render.MsgPack] = openapi.MediaType{Schema: schema}
+	r.Content[render.CBOR] = openapi.MediaType{Schema: schema}
+	if protoMessage != "" {
+		r.Content[render.Protobuf] = openapi.MediaType{Schema: &openapi.Schema{
+			Type:        "string",
+			Format:      "binary",
+			Description: "Serialized " + protoMessage + " from proto/pokedex/v1/pokedex.proto",
+		}}
+	}
+	return r
+}
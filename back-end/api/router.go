@@ -0,0 +1,120 @@
+// Package api wires the Gin router: middleware, routes and handlers.
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/cors"
+	"GO-Mongo/gql"
+	"GO-Mongo/httpcache"
+	"GO-Mongo/logger"
+	"GO-Mongo/openapi"
+	"GO-Mongo/quiz"
+	"GO-Mongo/repository"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Options carries the dependencies of NewRouter.
+type Options struct {
+	Logger    *slog.Logger
+	Pokemon   repository.PokemonRepository
+	HTTPCache httpcache.Policy
+	CORS      cors.Config
+
+	// Evolutions backs the evolution chains. When nil, every Pokemon is a
+	// chain of one stage.
+	Evolutions repository.EvolutionRepository
+	// Abilities backs the ability routes. When nil, no ability is known.
+	Abilities repository.AbilityRepository
+	// Moves backs the move and learnset routes. When nil, no move is known.
+	Moves repository.MoveRepository
+
+	// Regions are the datasets exposed over GraphQL. When empty, GraphQL
+	// serves Pokemon as the single region "kanto", like the REST routes.
+	Regions []gql.Region
+	// GraphiQL serves the GraphQL playground at /graphiql; enable it in
+	// development only.
+	GraphiQL bool
+}
+
+// NewRouter builds the HTTP handler for the whole API.
+func NewRouter(opts Options) (*gin.Engine, error) {
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.Use(logger.RequestID(opts.Logger), logger.AccessLog(), apierror.Middleware(), logger.Recovery())
+
+	corsMiddleware, err := cors.New(opts.CORS)
+	if err != nil {
+		return nil, err
+	}
+	r.Use(corsMiddleware)
+
+	r.NoRoute(apierror.NoRoute)
+	r.NoMethod(apierror.NoMethod)
+
+	spec := openapi.New(openapi.Info{
+		Title:       "Pokedex API",
+		Version:     "1.0.0",
+		Description: "Read API over the Pokedex datasets. Errors are returned as application/problem+json.",
+	})
+
+	// API routes
+	api := r.Group("/api")
+	cachePolicy := opts.HTTPCache
+	cachePolicy.Revision = responseRevision
+	cachePolicy.Dynamic = map[string]bool{randomRoute: true, dailyRoute: true, quizSessionRoute: true}
+	httpCache := httpcache.Middleware(opts.Pokemon, cachePolicy)
+	// The deprecation headers go first so that 304s answered by the cache
+	// carry them too.
+	v1Routes := api.Group("", deprecatedVersion("/api", "/api/v2", v1Deprecated, v1Sunset), httpCache)
+	(&pokemonHandler{repo: opts.Pokemon, version: v1}).register(documented{group: v1Routes, spec: spec})
+	(&pokemonHandler{repo: opts.Pokemon, version: v2}).register(documented{group: api.Group("/v2", httpCache), spec: spec})
+	// Routes added after v2 have a single shape and are not versioned.
+	routes := documented{group: api.Group("", httpCache), spec: spec}
+	(&typeHandler{repo: opts.Pokemon}).register(routes)
+	(&teamHandler{repo: opts.Pokemon}).register(routes)
+	(&compareHandler{repo: opts.Pokemon}).register(routes)
+
+	regions := opts.Regions
+	if len(regions) == 0 {
+		regions = []gql.Region{{Name: "kanto", Pokemon: opts.Pokemon}}
+	}
+	evolutions := opts.Evolutions
+	if evolutions == nil {
+		evolutions = repository.NewMemoryEvolutions(nil)
+	}
+	(&evolutionHandler{repo: opts.Pokemon, evolutions: evolutions, regions: regions}).register(routes)
+	(&breedingHandler{regions: regions}).register(routes)
+	(&statHandler{regions: regions}).register(routes)
+	(&damageHandler{regions: regions}).register(routes)
+	(&analyticsHandler{regions: regions}).register(routes)
+	(&similarHandler{regions: regions}).register(routes)
+	(&featuredHandler{regions: regions}).register(routes)
+	(&quizHandler{regions: regions, store: quiz.NewStore(quizSessions, quizTTL)}).register(routes)
+	abilities := opts.Abilities
+	if abilities == nil {
+		abilities = repository.NewMemoryAbilities(nil)
+	}
+	(&abilityHandler{abilities: abilities, regions: regions}).register(routes)
+	moves := opts.Moves
+	if moves == nil {
+		moves = repository.NewMemoryMoves(nil, nil)
+	}
+	(&moveHandler{moves: moves, regions: regions}).register(routes)
+	service, err := gql.New(regions)
+	if err != nil {
+		return nil, err
+	}
+	(&graphqlHandler{service: service}).register(documented{group: r.Group("/graphql"), spec: spec})
+	if opts.GraphiQL {
+		r.GET("/graphiql", serveGraphiQL)
+	}
+
+	// The document describes the code, not the dataset, so it stays outside
+	// the dataset-versioned HTTP cache.
+	r.GET("/api/openapi.json", serveSpec(spec))
+	r.GET("/api/docs", serveSwaggerUI)
+
+	return r, nil
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/gql"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"GO-Mongo/similar"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limits of the similar-Pokemon route.
+const (
+	defaultSimilarLimit = 5
+	maxSimilarLimit     = 50
+	maxSimilarWeight    = 10
+)
+
+// similarHandler finds similar Pokemon across every region with an index
+// kept in memory. The index is rebuilt when a region's dataset version
+// changes, i.e. after an import.
+type similarHandler struct {
+	regions []gql.Region
+
+	mu      sync.Mutex
+	index   *similar.Index
+	version string // dataset versions the index was built from
+}
+
+// SimilarPokemon is a Pokemon and its nearest neighbours.
+type SimilarPokemon struct {
+	DexNumber string             `json:"dex_number"`
+	Name      string             `json:"name"`
+	Metric    similar.Metric     `json:"metric"`
+	Similar   []similar.Neighbor `json:"similar" doc:"Closest first"`
+}
+
+func (h *similarHandler) register(rt documented) {
+	weight := func(name, traits string) openapi.Parameter {
+		return openapi.QueryParam(name, "Weight of shared "+traits+", 0-"+strconv.Itoa(maxSimilarWeight)+" (default: 0); 1 outweighs any stat difference", openapi.Number())
+	}
+
+	rt.GET("/pokemon/:id/similar", &openapi.Operation{
+		OperationID: "getSimilarPokemon",
+		Summary:     "Pokemon of every region with the closest base stats, optionally favouring shared types, abilities and egg groups",
+		Tags:        []string{"pokemon"},
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("id", "National Dex number, e.g. 25", openapi.Integer()),
+			openapi.QueryParam("limit", "Number of Pokemon, 1-"+strconv.Itoa(maxSimilarLimit)+" (default: "+strconv.Itoa(defaultSimilarLimit)+")", openapi.Integer()),
+			openapi.QueryParam("metric", "Distance over normalized base stats (default: euclidean)", openapi.Enum(metrics()...)),
+			weight("types", "types"),
+			weight("abilities", "abilities"),
+			weight("egg_groups", "egg groups"),
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The similar Pokemon", rt.spec.SchemaOf(SimilarPokemon{}), "")}),
+	}, h.getSimilar)
+}
+
+func (h *similarHandler) getSimilar(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		_ = c.Error(apierror.InvalidParam("id", "must be a positive dex number"))
+		return
+	}
+	limit, ok := intQuery(c, "limit", 1, maxSimilarLimit)
+	if !ok {
+		return
+	}
+	if limit == 0 {
+		limit = defaultSimilarLimit
+	}
+	q := similar.Query{Metric: similar.Euclidean, Limit: limit}
+	if raw := c.Query("metric"); raw != "" {
+		if q.Metric, err = similar.ParseMetric(raw); err != nil {
+			_ = c.Error(apierror.InvalidParam("metric", "must be one of "+strings.Join(metrics(), ", ")))
+			return
+		}
+	}
+	weights := []struct {
+		name string
+		w    *float64
+	}{
+		{"types", &q.Weights.Types},
+		{"abilities", &q.Weights.Abilities},
+		{"egg_groups", &q.Weights.EggGroups},
+	}
+	for _, p := range weights {
+		if *p.w, ok = weightQuery(c, p.name); !ok {
+			return
+		}
+	}
+
+	index, ok := h.load(c)
+	if !ok {
+		return
+	}
+	dex := repository.DexNumber(id)
+	neighbors, err := index.Nearest(dex, q)
+	if errors.Is(err, similar.ErrNotFound) {
+		_ = c.Error(apierror.NotFound("Pokemon #" + idStr + " not found"))
+		return
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, SimilarPokemon{DexNumber: dex, Name: index.Name(dex), Metric: q.Metric, Similar: neighbors})
+}
+
+// load returns the index, rebuilding it first when the dataset version of a
+// region changed since it was built.
+func (h *similarHandler) load(c *gin.Context) (*similar.Index, bool) {
+	version, ok := datasetVersion(c, h.regions)
+	if !ok {
+		return nil, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.index != nil && h.version == version {
+		return h.index, true
+	}
+	regions := make([]similar.Region, 0, len(h.regions))
+	for _, region := range h.regions {
+		pokemons, err := region.Pokemon.List(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return nil, false
+		}
+		regions = append(regions, similar.Region{Name: region.Name, Pokemons: pokemons})
+	}
+	h.index, h.version = similar.Build(regions), version
+	return h.index, true
+}
+
+// datasetVersion joins the dataset versions of regions, so anything built
+// from them can tell when it is stale.
+func datasetVersion(c *gin.Context, regions []gql.Region) (string, bool) {
+	versions := make([]string, len(regions))
+	for i, region := range regions {
+		v, err := region.Pokemon.Version(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return "", false
+		}
+		versions[i] = region.Name + "@" + strconv.FormatInt(v.Version, 10) + "/" + v.UpdatedAt.String()
+	}
+	return strings.Join(versions, ","), true
+}
+
+// weightQuery parses an optional trait weight.
+func weightQuery(c *gin.Context, name string) (float64, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, true
+	}
+	w, err := strconv.ParseFloat(raw, 64)
+	// ParseFloat accepts NaN and Inf, which would turn every score into NaN.
+	if err != nil || math.IsNaN(w) || math.IsInf(w, 0) || w < 0 || w > maxSimilarWeight {
+		_ = c.Error(apierror.InvalidParam(name, "must be a number between 0 and "+strconv.Itoa(maxSimilarWeight)))
+		return 0, false
+	}
+	return w, true
+}
+
+func metrics() []string {
+	out := make([]string, len(similar.Metrics))
+	for i, m := range similar.Metrics {
+		out[i] = string(m)
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSimilarRejectsInvalidWeights(t *testing.T) {
+	r := newTestRouter(t, nil)
+	tests := []struct {
+		query string
+		param string
+	}{
+		{"types=NaN", "types"},
+		{"abilities=Inf", "abilities"},
+		{"egg_groups=-Inf", "egg_groups"},
+		{"types=-1", "types"},
+		{"types=11", "types"},
+		// Several bad weights report the first in the documented order.
+		{"egg_groups=x&abilities=NaN&types=x", "types"},
+		{"egg_groups=x&abilities=NaN", "abilities"},
+	}
+	for _, tt := range tests {
+		w := serve(r, http.MethodGet, "/api/pokemon/25/similar?"+tt.query, "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400: %s", tt.query, w.Code, w.Body)
+			continue
+		}
+		var problem apierror.Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || len(problem.Details) != 1 || problem.Details[0].Field != tt.param {
+			t.Errorf("%s: problem %s, want one error on %s", tt.query, w.Body, tt.param)
+		}
+	}
+}
+
+func TestSimilarAcrossRegions(t *testing.T) {
+	r := newTestRouter(t, nil)
+	w := serve(r, http.MethodGet, "/api/pokemon/152/similar?types=1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got SimilarPokemon
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Chikorita" || len(got.Similar) != defaultSimilarLimit {
+		t.Errorf("got %s with %d similar, want Chikorita with %d", got.Name, len(got.Similar), defaultSimilarLimit)
+	}
+}
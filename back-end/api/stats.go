@@ -0,0 +1,222 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/gql"
+	"GO-Mongo/openapi"
+	"GO-Mongo/stats"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Calculations default to level 50 and report stat ranges at 50 and 100,
+// the levels of competitive play.
+const (
+	defaultLevel   = 50
+	maxRangeLevels = 10
+)
+
+var defaultRangeLevels = []int{50, 100}
+
+// statHandler computes actual stats from the base stats of Pokemon of every
+// region.
+type statHandler struct {
+	regions []gql.Region
+}
+
+// StatNature is the nature used and the stats it changes.
+type StatNature struct {
+	Name    string `json:"name"`
+	Raised  string `json:"raised,omitempty" doc:"Stat raised by 10%; empty for neutral natures"`
+	Lowered string `json:"lowered,omitempty" doc:"Stat lowered by 10%; empty for neutral natures"`
+}
+
+// StatCalculation is a Pokemon's actual stats for one spread, and the stat
+// ranges at several levels.
+type StatCalculation struct {
+	DexNumber  string        `json:"dex_number"`
+	Name       string        `json:"name"`
+	Generation int           `json:"generation"`
+	Level      int           `json:"level"`
+	Nature     *StatNature   `json:"nature" doc:"Null in generations 1 and 2, which have no natures"`
+	BaseStats  stats.Values  `json:"base_stats"`
+	IVs        stats.Values  `json:"ivs" doc:"DVs in generations 1 and 2, with the derived HP DV and Sp. Def sharing Sp. Atk's Special DV"`
+	EVs        stats.Values  `json:"evs" doc:"Stat experience in generations 1 and 2"`
+	Stats      stats.Values  `json:"stats"`
+	Ranges     []stats.Range `json:"ranges"`
+}
+
+func (h *statHandler) register(rt documented) {
+	rt.GET("/pokemon/:id/stats/calc", &openapi.Operation{
+		OperationID: "calculateStats",
+		Summary:     "Actual stats from level, IVs, EVs and nature, and stat ranges by level",
+		Tags:        []string{"pokemon"},
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("id", "National Dex number, e.g. 25", openapi.Integer()),
+			openapi.QueryParam("level", "Level, 1-100 (default: 50)", openapi.Integer()),
+			openapi.QueryParam("nature", "Nature, e.g. adamant (default: a neutral nature); not allowed in generations 1 and 2", openapi.String()),
+			openapi.QueryParam("ivs", "One value for every stat, or six comma-separated values in the order HP, Attack, Defense, Sp. Atk, Sp. Def, Speed: 0-31, or DVs 0-15 in generations 1 and 2 (default: the maximum)", openapi.String()),
+			openapi.QueryParam("evs", "Like ivs: 0-252 (0-255 in generations 3-5) totalling at most 510, or stat experience 0-65535 in generations 1 and 2 (default: 0)", openapi.String()),
+			openapi.QueryParam("gen", "Game generation of the formula, 1-"+strconv.Itoa(stats.LatestGeneration)+" (default: latest)", openapi.Integer()),
+			openapi.QueryParam("levels", "Comma-separated levels of the stat ranges, at most "+strconv.Itoa(maxRangeLevels)+" (default: 50,100)", openapi.String()),
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The stats", rt.spec.SchemaOf(StatCalculation{}), "")}),
+	}, h.calculate)
+}
+
+func (h *statHandler) calculate(c *gin.Context) {
+	gen, ok := intQuery(c, "gen", stats.FirstGeneration, stats.LatestGeneration)
+	if !ok {
+		return
+	}
+	if gen == 0 {
+		gen = stats.LatestGeneration
+	}
+	level, ok := intQuery(c, "level", 1, 100)
+	if !ok {
+		return
+	}
+	if level == 0 {
+		level = defaultLevel
+	}
+
+	nature := stats.Neutral
+	if name := strings.TrimSpace(c.Query("nature")); name != "" {
+		if gen < stats.ModernGeneration {
+			_ = c.Error(apierror.InvalidParam("nature", "natures do not exist before generation "+strconv.Itoa(stats.ModernGeneration)))
+			return
+		}
+		n, err := stats.ParseNature(name)
+		if err != nil {
+			_ = c.Error(apierror.InvalidParam("nature", strconv.Quote(name)+" is not a nature"))
+			return
+		}
+		nature = n
+	}
+
+	maxIV, maxEV := stats.MaxIVFor(gen), stats.MaxEVFor(gen)
+	ivs, ok := spreadQuery(c, "ivs", maxIV, maxIV)
+	if !ok {
+		return
+	}
+	evs, ok := spreadQuery(c, "evs", maxEV, 0)
+	if !ok {
+		return
+	}
+	if gen >= stats.ModernGeneration && evs.Total() > stats.MaxEVTotal {
+		_ = c.Error(apierror.InvalidParam("evs", "must total at most "+strconv.Itoa(stats.MaxEVTotal)))
+		return
+	}
+	levels, ok := levelsQuery(c)
+	if !ok {
+		return
+	}
+
+	pokemon, ok := regionPokemonParam(c, h.regions)
+	if !ok {
+		return
+	}
+	base := stats.FromBase(pokemon.BaseStats())
+
+	out := StatCalculation{
+		DexNumber:  pokemon.DexNumber,
+		Name:       pokemon.Name,
+		Generation: gen,
+		Level:      level,
+		BaseStats:  base,
+		IVs:        ivs,
+		EVs:        evs,
+		Ranges:     make([]stats.Range, 0, len(levels)),
+	}
+	if gen < stats.ModernGeneration {
+		out.IVs = stats.DVs(ivs)
+	} else {
+		out.Nature = &StatNature{Name: nature.Name}
+		if !nature.Neutral() {
+			out.Nature.Raised, out.Nature.Lowered = nature.Up.String(), nature.Down.String()
+		}
+	}
+
+	var err error
+	out.Stats, err = stats.Calculate(stats.Input{Generation: gen, Level: level, Base: base, IVs: ivs, EVs: evs, Nature: nature})
+	if err != nil {
+		_ = c.Error(statError(err))
+		return
+	}
+	for _, l := range levels {
+		r, err := stats.RangeAt(gen, l, base)
+		if err != nil {
+			_ = c.Error(statError(err))
+			return
+		}
+		out.Ranges = append(out.Ranges, r)
+	}
+	renderer.Render(c, http.StatusOK, out)
+}
+
+// spreadQuery parses a query parameter holding one value for every stat or
+// six comma-separated values, each between 0 and max.
+func spreadQuery(c *gin.Context, name string, max, def int) (stats.Values, bool) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return stats.Uniform(def), true
+	}
+	reason := "must be one value or " + strconv.Itoa(len(stats.All)) + " comma-separated values, each between 0 and " + strconv.Itoa(max)
+	parts := strings.Split(raw, ",")
+	if len(parts) != 1 && len(parts) != len(stats.All) {
+		_ = c.Error(apierror.InvalidParam(name, reason))
+		return stats.Values{}, false
+	}
+	values := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n < 0 || n > max {
+			_ = c.Error(apierror.InvalidParam(name, reason))
+			return stats.Values{}, false
+		}
+		values[i] = n
+	}
+	if len(values) == 1 {
+		return stats.Uniform(values[0]), true
+	}
+	var v stats.Values
+	for i, s := range stats.All {
+		v.Set(s, values[i])
+	}
+	return v, true
+}
+
+// levelsQuery parses the levels of the stat ranges.
+func levelsQuery(c *gin.Context) ([]int, bool) {
+	raw := strings.TrimSpace(c.Query("levels"))
+	if raw == "" {
+		return defaultRangeLevels, true
+	}
+	var levels []int
+	for _, p := range strings.Split(raw, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n < 1 || n > 100 {
+			_ = c.Error(apierror.InvalidParam("levels", "must be comma-separated levels between 1 and 100"))
+			return nil, false
+		}
+		levels = append(levels, n)
+	}
+	if len(levels) > maxRangeLevels {
+		_ = c.Error(apierror.InvalidParam("levels", "must list at most "+strconv.Itoa(maxRangeLevels)+" levels"))
+		return nil, false
+	}
+	return levels, true
+}
+
+// statError reports invalid input to the formula, such as a malformed base
+// stat in the dataset, as a 400.
+func statError(err error) error {
+	if errors.Is(err, stats.ErrInvalidInput) {
+		return apierror.InvalidInput(err.Error())
+	}
+	return err
+}
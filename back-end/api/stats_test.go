@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCalculateStatsAcrossRegions(t *testing.T) {
+	r := newTestRouter(t, nil)
+	tests := []struct {
+		id, name string
+		hp       int // at level 50 with perfect IVs and no EVs
+	}{
+		{"6", "Charizard", 153},
+		{"152", "Chikorita", 120},
+	}
+	for _, tt := range tests {
+		w := serve(r, http.MethodGet, "/api/pokemon/"+tt.id+"/stats/calc", "")
+		if w.Code != http.StatusOK {
+			t.Errorf("#%s: status %d: %s", tt.id, w.Code, w.Body)
+			continue
+		}
+		var got StatCalculation
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != tt.name || got.Stats.HP != tt.hp {
+			t.Errorf("#%s = %s with %d HP, want %s with %d", tt.id, got.Name, got.Stats.HP, tt.name, tt.hp)
+		}
+	}
+	if w := serve(r, http.MethodGet, "/api/pokemon/252/stats/calc", ""); w.Code != http.StatusNotFound {
+		t.Errorf("#252: status %d, want 404", w.Code)
+	}
+}
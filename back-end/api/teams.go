@@ -0,0 +1,74 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"GO-Mongo/team"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// teamHandler analyzes parties of Pokemon.
+type teamHandler struct {
+	repo repository.PokemonRepository
+}
+
+// TeamRequest is the body of POST /teams/analyze.
+type TeamRequest struct {
+	Pokemon []batchID `json:"pokemon" doc:"One to six dex numbers or names"`
+}
+
+func (h *teamHandler) register(rt documented) {
+	rt.POST("/teams/analyze", &openapi.Operation{
+		OperationID: "analyzeTeam",
+		Summary:     "Defensive and offensive type coverage, stat averages and suggestions for a team",
+		Tags:        []string{"teams"},
+		Parameters: []openapi.Parameter{
+			genParam(),
+		},
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]openapi.MediaType{"application/json": {Schema: rt.spec.SchemaOf(TeamRequest{})}},
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The analysis", rt.spec.SchemaOf(team.Analysis{}), "")}),
+	}, h.analyze)
+}
+
+func (h *teamHandler) analyze(c *gin.Context) {
+	chart, _, ok := chartParam(c)
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBody)
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		_ = c.Error(apierror.InvalidInput("Request body must be a JSON object with a pokemon array of dex numbers and names"))
+		return
+	}
+	if len(req.Pokemon) == 0 || len(req.Pokemon) > team.MaxSize {
+		_ = c.Error(apierror.InvalidParam("pokemon", "must list 1 to "+strconv.Itoa(team.MaxSize)+" Pokemon"))
+		return
+	}
+
+	ids := make([]string, len(req.Pokemon))
+	for i, id := range req.Pokemon {
+		ids[i] = string(id)
+	}
+	members, ok := lookupAll(c, h.repo, "pokemon", ids)
+	if !ok {
+		return
+	}
+
+	pool, err := h.repo.List(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderer.Render(c, http.StatusOK, team.Analyze(chart, members, pool))
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/models"
+	"GO-Mongo/openapi"
+	"GO-Mongo/repository"
+	"GO-Mongo/typechart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// typeHandler serves the type effectiveness chart and the matchups of
+// individual Pokemon computed from it.
+type typeHandler struct {
+	repo repository.PokemonRepository
+}
+
+// TypeChart is the full effectiveness matrix of one generation.
+type TypeChart struct {
+	Generation int                           `json:"generation"`
+	Types      []string                      `json:"types" doc:"Types of the generation in the games' order"`
+	Matrix     map[string]map[string]float64 `json:"matrix" doc:"Multiplier by attacking type, then defending type"`
+}
+
+// TypeMultipliers lists multipliers for one attacking type or one defending
+// type combination.
+type TypeMultipliers struct {
+	Generation  int                    `json:"generation"`
+	Types       []string               `json:"types" doc:"The attacking type, or the one or two defending types"`
+	Multipliers []typechart.Multiplier `json:"multipliers"`
+}
+
+// PokemonMatchups classifies every attacking type against a Pokemon.
+type PokemonMatchups struct {
+	Generation int      `json:"generation"`
+	DexNumber  string   `json:"dex_number"`
+	Name       string   `json:"name"`
+	Types      []string `json:"types"`
+	// IgnoredTypes are the Pokemon's types that did not exist yet in the
+	// requested generation, e.g. Fairy before generation 6.
+	IgnoredTypes []string `json:"ignored_types" doc:"Types of the Pokemon missing from the generation's chart"`
+	typechart.Matchups
+}
+
+// PokemonCounters lists the attacking types that hit a Pokemon hardest.
+type PokemonCounters struct {
+	Generation   int                    `json:"generation"`
+	DexNumber    string                 `json:"dex_number"`
+	Name         string                 `json:"name"`
+	Types        []string               `json:"types"`
+	IgnoredTypes []string               `json:"ignored_types" doc:"Types of the Pokemon missing from the generation's chart"`
+	Attackers    []typechart.Multiplier `json:"attackers" doc:"Super effective types, strongest first; the best neutral types if none is"`
+}
+
+func (h *typeHandler) register(rt documented) {
+	tags := []string{"types"}
+	gen := genParam()
+	typeParam := openapi.PathParam("type", "Type name, e.g. Fire", openapi.String())
+	id := openapi.PathParam("id", "National Dex number, e.g. 25", openapi.Integer())
+
+	rt.GET("/types/chart", &openapi.Operation{
+		OperationID: "getTypeChart",
+		Summary:     "Full type effectiveness chart",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{gen},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The chart", rt.spec.SchemaOf(TypeChart{}), "")}),
+	}, h.getChart)
+
+	rt.GET("/types/:type/attacking", &openapi.Operation{
+		OperationID: "getAttackingMultipliers",
+		Summary:     "Multipliers of an attacking type against each type",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{typeParam, gen},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Multipliers by defending type", rt.spec.SchemaOf(TypeMultipliers{}), "")}),
+	}, h.getAttacking)
+
+	rt.GET("/types/:type/defending", &openapi.Operation{
+		OperationID: "getDefendingMultipliers",
+		Summary:     "Multipliers of each attacking type against a type, or a pair of types",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{
+			typeParam,
+			openapi.QueryParam("with", "Second defending type, e.g. Flying", openapi.String()),
+			gen,
+		},
+		Responses: openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("Multipliers by attacking type", rt.spec.SchemaOf(TypeMultipliers{}), "")}),
+	}, h.getDefending)
+
+	rt.GET("/pokemon/:id/matchups", &openapi.Operation{
+		OperationID: "getPokemonMatchups",
+		Summary:     "Weaknesses, resistances and immunities of a Pokemon from both its types",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{id, gen},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The matchups", rt.spec.SchemaOf(PokemonMatchups{}), "")}),
+	}, h.getMatchups)
+
+	rt.GET("/pokemon/:id/counters", &openapi.Operation{
+		OperationID: "getPokemonCounters",
+		Summary:     "Best attacking types against a Pokemon",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{id, gen},
+		Responses:   openapi.Responses(map[int]openapi.Response{http.StatusOK: negotiated("The best attacking types", rt.spec.SchemaOf(PokemonCounters{}), "")}),
+	}, h.getCounters)
+}
+
+func (h *typeHandler) getChart(c *gin.Context) {
+	chart, gen, ok := chartParam(c)
+	if !ok {
+		return
+	}
+
+	types := chart.Types()
+	matrix := make(map[string]map[string]float64, len(types))
+	for _, t := range types {
+		row, _ := chart.Attacking(t)
+		matrix[t] = make(map[string]float64, len(row))
+		for _, m := range row {
+			matrix[t][m.Type] = m.Multiplier
+		}
+	}
+
+	renderer.Render(c, http.StatusOK, TypeChart{Generation: gen, Types: types, Matrix: matrix})
+}
+
+func (h *typeHandler) getAttacking(c *gin.Context) {
+	chart, gen, ok := chartParam(c)
+	if !ok {
+		return
+	}
+	attacking, ok := typeParam(c, chart, gen, c.Param("type"))
+	if !ok {
+		return
+	}
+
+	multipliers, _ := chart.Attacking(attacking)
+	renderer.Render(c, http.StatusOK, TypeMultipliers{Generation: gen, Types: []string{attacking}, Multipliers: multipliers})
+}
+
+func (h *typeHandler) getDefending(c *gin.Context) {
+	chart, gen, ok := chartParam(c)
+	if !ok {
+		return
+	}
+	defending, ok := typeParam(c, chart, gen, c.Param("type"))
+	if !ok {
+		return
+	}
+	types := []string{defending}
+	if with := c.Query("with"); with != "" {
+		second, ok := chart.Canonical(with)
+		if !ok {
+			_ = c.Error(apierror.InvalidParam("with", "is not a type in generation "+strconv.Itoa(gen)))
+			return
+		}
+		if second != defending {
+			types = append(types, second)
+		}
+	}
+
+	multipliers, _ := chart.Defending(types...)
+	renderer.Render(c, http.StatusOK, TypeMultipliers{Generation: gen, Types: types, Multipliers: multipliers})
+}
+
+func (h *typeHandler) getMatchups(c *gin.Context) {
+	chart, gen, ok := chartParam(c)
+	if !ok {
+		return
+	}
+	pokemon, ok := pokemonParam(c, h.repo)
+	if !ok {
+		return
+	}
+
+	known, ignored := pokemonTypes(chart, pokemon)
+	matchups, _ := chart.Matchups(known...)
+	renderer.Render(c, http.StatusOK, PokemonMatchups{
+		Generation:   gen,
+		DexNumber:    pokemon.DexNumber,
+		Name:         pokemon.Name,
+		Types:        pokemon.Types(),
+		IgnoredTypes: ignored,
+		Matchups:     matchups,
+	})
+}
+
+func (h *typeHandler) getCounters(c *gin.Context) {
+	chart, gen, ok := chartParam(c)
+	if !ok {
+		return
+	}
+	pokemon, ok := pokemonParam(c, h.repo)
+	if !ok {
+		return
+	}
+
+	known, ignored := pokemonTypes(chart, pokemon)
+	attackers, _ := chart.BestAttackers(known...)
+	renderer.Render(c, http.StatusOK, PokemonCounters{
+		Generation:   gen,
+		DexNumber:    pokemon.DexNumber,
+		Name:         pokemon.Name,
+		Types:        pokemon.Types(),
+		IgnoredTypes: ignored,
+		Attackers:    attackers,
+	})
+}
+
+// genParam documents the optional gen query parameter read by chartParam.
+func genParam() openapi.Parameter {
+	return openapi.QueryParam("gen", "Game generation of the type chart, 1-"+strconv.Itoa(typechart.LatestGeneration)+" (default: latest)", openapi.Integer())
+}
+
+// chartParam selects the chart of the optional gen query parameter.
+func chartParam(c *gin.Context) (*typechart.Chart, int, bool) {
+	gen, ok := intQuery(c, "gen", 1, typechart.LatestGeneration)
+	if !ok {
+		return nil, 0, false
+	}
+	if gen == 0 {
+		gen = typechart.LatestGeneration
+	}
+	chart, err := typechart.ForGeneration(gen)
+	if err != nil {
+		_ = c.Error(apierror.InvalidParam("gen", err.Error()))
+		return nil, 0, false
+	}
+	return chart, gen, true
+}
+
+// typeParam resolves a type path parameter; a type the generation does not
+// have is not found.
+func typeParam(c *gin.Context, chart *typechart.Chart, gen int, name string) (string, bool) {
+	t, ok := chart.Canonical(name)
+	if !ok {
+		_ = c.Error(apierror.NotFound("Type " + strconv.Quote(name) + " does not exist in generation " + strconv.Itoa(gen)))
+		return "", false
+	}
+	return t, true
+}
+
+// pokemonTypes splits the Pokemon's types into those the chart knows and
+// those it does not.
+func pokemonTypes(chart *typechart.Chart, p models.Pokemon) (known, ignored []string) {
+	ignored = []string{}
+	for _, t := range p.Types() {
+		if canonical, ok := chart.Canonical(t); ok {
+			known = append(known, canonical)
+		} else {
+			ignored = append(ignored, t)
+		}
+	}
+	return known, ignored
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"GO-Mongo/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The original /api routes (v1) return the documents as stored, with every
+// field a string. They are deprecated in favour of /api/v2 and removed at
+// v1Sunset.
+var (
+	v1Deprecated = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	v1Sunset     = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// responseRevision is mixed into ETags. Bump it when a change alters existing
+// response bodies without a dataset change, e.g. "1" added egg groups to v1
+// and "2" counted type_02 in the stats' type distribution, so clients stop
+// revalidating copies of the old shape.
+const responseRevision = "2"
+
+// apiVersion is the per-version part of the Pokemon routes: how results are
+// serialized and documented. Handlers and repository calls are shared.
+type apiVersion struct {
+	// operationSuffix keeps OpenAPI operation IDs unique across versions.
+	operationSuffix string
+	deprecated      bool
+	// model is a zero value of the Pokemon representation, for the schema.
+	model       any
+	pokemon     func(models.Pokemon) any
+	pokemonList func([]models.Pokemon) any
+}
+
+var (
+	v1 = apiVersion{
+		deprecated:  true,
+		model:       models.Pokemon{},
+		pokemon:     func(p models.Pokemon) any { return p },
+		pokemonList: func(ps []models.Pokemon) any { return ps },
+	}
+	v2 = apiVersion{
+		operationSuffix: "V2",
+		model:           PokemonV2{},
+		pokemon:         func(p models.Pokemon) any { return NewPokemonV2(p) },
+		pokemonList: func(ps []models.Pokemon) any {
+			out := make([]PokemonV2, len(ps))
+			for i, p := range ps {
+				out[i] = NewPokemonV2(p)
+			}
+			return out
+		},
+	}
+)
+
+// PokemonV2 is the typed Pokemon served under /api/v2: stats are numbers,
+// types, abilities and egg groups are arrays and the legendary flag is a
+// boolean.
+type PokemonV2 struct {
+	ID            int              `json:"id"`
+	DexNumber     string           `json:"dex_number"`
+	Name          string           `json:"name"`
+	Types         []string         `json:"types"`
+	Abilities     []string         `json:"abilities"`
+	HiddenAbility string           `json:"hidden_ability"`
+	EggGroups     []string         `json:"egg_groups"`
+	IsLegendary   bool             `json:"is_legendary"`
+	Bio           string           `json:"bio"`
+	BaseStats     models.BaseStats `json:"base_stats"`
+
+	// source is kept for the protobuf form, which is shared with v1.
+	source models.Pokemon
+}
+
+// NewPokemonV2 converts a stored document to its v2 representation.
+func NewPokemonV2(p models.Pokemon) PokemonV2 {
+	return PokemonV2{
+		ID:            p.Number(),
+		DexNumber:     p.DexNumber,
+		Name:          p.Name,
+		Types:         p.Types(),
+		Abilities:     p.Abilities(),
+		HiddenAbility: strings.TrimSpace(p.HiddenAbility),
+		EggGroups:     p.EggGroups(),
+		IsLegendary:   p.Legendary(),
+		Bio:           p.Bio,
+		BaseStats:     p.BaseStats(),
+		source:        p,
+	}
+}
+
+// deprecatedVersion marks every response of a superseded API version with
+// the Deprecation (RFC 9745) and Sunset (RFC 8594) headers, and links the
+// same resource under successorBase.
+func deprecatedVersion(basePath, successorBase string, since, sunset time.Time) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	sunsetValue := sunset.UTC().Format(http.TimeFormat)
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("Deprecation", deprecation)
+		h.Set("Sunset", sunsetValue)
+		successor := successorBase + strings.TrimPrefix(c.Request.URL.Path, basePath)
+		h.Add("Link", "<"+successor+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"GO-Mongo/repository"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// versionedRepository reports a recorded dataset version, so responses get
+// version ETags answered before the handlers run.
+type versionedRepository struct {
+	repository.PokemonRepository
+}
+
+func (versionedRepository) Version(context.Context) (repository.DatasetVersion, error) {
+	return repository.DatasetVersion{Version: 1, UpdatedAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func TestDeprecatedVersionHeadersOnNotModified(t *testing.T) {
+	r := newTestRouter(t, func(o *Options) { o.Pokemon = versionedRepository{o.Pokemon} })
+	for _, path := range []string{"/api/pokemon/25", "/api/v2/pokemon/25"} {
+		first := serve(r, http.MethodGet, path, "")
+		if first.Code != http.StatusOK || first.Header().Get("ETag") == "" {
+			t.Fatalf("GET %s: status %d, ETag %q", path, first.Code, first.Header().Get("ETag"))
+		}
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusNotModified {
+			t.Fatalf("GET %s again: status %d, want 304", path, w.Code)
+		}
+
+		deprecated := path == "/api/pokemon/25"
+		for _, h := range []string{"Deprecation", "Sunset", "Link"} {
+			if got := w.Header().Get(h); (got != "") != deprecated || got != first.Header().Get(h) {
+				t.Errorf("GET %s 304: %s = %q, want %q as on the 200", path, h, got, first.Header().Get(h))
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Package apierror defines the API's error model and renders it as RFC 7807
+// application/problem+json. Handlers report failures with c.Error and return;
+// Middleware turns the last recorded error into the response.
+package apierror
+
+import (
+	"GO-Mongo/repository"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code is the stable, machine-readable identifier clients switch on.
+type Code string
+
+const (
+	CodeInvalidInput     Code = "invalid_input"
+	CodeNotFound         Code = "not_found"
+	CodeMethodNotAllowed Code = "method_not_allowed"
+	CodeNotAcceptable    Code = "not_acceptable"
+	CodeTimeout          Code = "timeout"
+	CodeUnavailable      Code = "unavailable"
+	CodeInternal         Code = "internal"
+)
+
+// Detail points at one offending part of a request.
+type Detail struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Error is an API error. Message is shown to clients; Err is the cause and is
+// only ever logged.
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Details []Detail
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// WithDetails returns e with the given details appended.
+func (e *Error) WithDetails(details ...Detail) *Error {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+// InvalidInput reports a malformed request.
+func InvalidInput(message string, details ...Detail) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: CodeInvalidInput, Message: message, Details: details}
+}
+
+// InvalidParam is InvalidInput for a single bad path or query parameter.
+func InvalidParam(field, reason string) *Error {
+	return InvalidInput("Invalid parameter "+field, Detail{Field: field, Reason: reason})
+}
+
+// NotFound reports a missing resource.
+func NotFound(message string) *Error {
+	return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: message}
+}
+
+// NotAcceptable reports that no representation matches the Accept header.
+func NotAcceptable(message string) *Error {
+	return &Error{Status: http.StatusNotAcceptable, Code: CodeNotAcceptable, Message: message}
+}
+
+// Internal wraps an unexpected failure. The cause is logged, never returned.
+func Internal(err error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Message: "An internal error occurred", Err: err}
+}
+
+// From converts any error into an *Error, mapping repository error kinds to
+// their HTTP status.
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: "Resource not found", Err: err}
+	case errors.Is(err, repository.ErrInvalidInput):
+		return &Error{Status: http.StatusBadRequest, Code: CodeInvalidInput, Message: "Invalid input", Err: err}
+	case errors.Is(err, repository.ErrTimeout):
+		return &Error{Status: http.StatusGatewayTimeout, Code: CodeTimeout, Message: "The data store did not respond in time", Err: err}
+	case errors.Is(err, repository.ErrUnavailable):
+		return &Error{Status: http.StatusServiceUnavailable, Code: CodeUnavailable, Message: "The data store is unavailable", Err: err}
+	default:
+		return Internal(err)
+	}
+}
@@ -0,0 +1,75 @@
+package apierror
+
+import (
+	"GO-Mongo/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of every error body.
+const ContentType = "application/problem+json"
+
+// Problem is the RFC 7807 body, extended with code, details and request_id.
+type Problem struct {
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Status    int      `json:"status"`
+	Detail    string   `json:"detail"`
+	Instance  string   `json:"instance,omitempty"`
+	Code      Code     `json:"code"`
+	Details   []Detail `json:"details,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+}
+
+// Problem builds the response body for e.
+func (e *Error) Problem(instance, requestID string) Problem {
+	return Problem{
+		Type:      "urn:pokedex:problem:" + string(e.Code),
+		Title:     http.StatusText(e.Status),
+		Status:    e.Status,
+		Detail:    e.Message,
+		Instance:  instance,
+		Code:      e.Code,
+		Details:   e.Details,
+		RequestID: requestID,
+	}
+}
+
+// Middleware renders the last error recorded with c.Error as problem+json
+// unless the handler already wrote a response. Server errors are logged with
+// their cause; client errors are logged at debug level.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+		Write(c, From(last.Err))
+	}
+}
+
+// Write logs e and sends it as the response.
+func Write(c *gin.Context, e *Error) {
+	l := logger.FromContext(c.Request.Context())
+	if e.Status >= http.StatusInternalServerError {
+		l.Error(e.Message, "code", e.Code, "status", e.Status, "error", e.Err)
+	} else {
+		l.Debug(e.Message, "code", e.Code, "status", e.Status, "error", e.Err)
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(e.Status, e.Problem(c.Request.URL.Path, logger.GetRequestID(c)))
+}
+
+// NoRoute answers unknown paths with a not_found problem.
+func NoRoute(c *gin.Context) {
+	Write(c, NotFound("No route matches "+c.Request.URL.Path))
+}
+
+// NoMethod answers known paths requested with an unsupported method.
+func NoMethod(c *gin.Context) {
+	Write(c, &Error{Status: http.StatusMethodNotAllowed, Code: CodeMethodNotAllowed, Message: c.Request.Method + " is not supported on " + c.Request.URL.Path})
+}
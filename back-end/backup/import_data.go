@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"log/slog"
 	"os"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,11 +22,17 @@ func main() {
 	}
 
 	ctx := context.Background()
-	db.Connect(cfg)
-	
+	if err := db.Connect(cfg, slog.Default()); err != nil {
+		log.Fatal("Failed to connect to MongoDB:", err)
+	}
+
 	// Check and create database and collections
-	db.CheckAndCreateDatabase(ctx, db.Collection.Database().Client(), cfg.MongoDB.Database)
-	db.CheckCollection(ctx, db.Collection.Database().Client(), cfg.MongoDB.Database, "kanto_pokemons")
+	if err := db.CheckAndCreateDatabase(ctx, slog.Default(), db.Collection.Database().Client(), cfg.MongoDB.Database); err != nil {
+		log.Fatal(err)
+	}
+	if err := db.CheckCollection(ctx, slog.Default(), db.Collection.Database().Client(), cfg.MongoDB.Database, "kanto_pokemons"); err != nil {
+		log.Fatal(err)
+	}
 
 	// Import Pokemon data
 	collection := db.Collection.Database().Collection("kanto_pokemons")
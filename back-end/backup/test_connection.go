@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 )
 
 func main() {
@@ -19,7 +20,9 @@ func main() {
 	fmt.Printf("Collection: %s\n", cfg.MongoDB.Collection)
 
 	ctx := context.Background()
-	db.Connect(cfg)
+	if err := db.Connect(cfg, slog.Default()); err != nil {
+		log.Fatal("Failed to connect to MongoDB:", err)
+	}
 	
 	// Test connection by listing collections
 	collections, err := db.Collection.Database().ListCollectionNames(ctx, struct{}{})
@@ -0,0 +1,199 @@
+// Package breeding groups Pokemon by egg group and decides which pairs can
+// produce an egg.
+package breeding
+
+import (
+	"GO-Mongo/models"
+	"slices"
+	"strings"
+)
+
+// Egg groups with special rules, as the datasets name them.
+const (
+	// Undiscovered is the group of Pokemon that cannot breed at all. The
+	// datasets also leave the egg groups of most legendaries empty, which
+	// means the same.
+	Undiscovered = "No Eggs Discovered"
+	// Ditto breeds with any Pokemon outside Undiscovered, except another
+	// Ditto.
+	Ditto = "Ditto"
+)
+
+// Gender is the gender distribution of a species.
+type Gender string
+
+// Gender distributions that affect breeding.
+const (
+	GenderMixed      Gender = "mixed"
+	GenderMale       Gender = "male"       // male only
+	GenderFemale     Gender = "female"     // female only
+	GenderGenderless Gender = "genderless" // breeds only with Ditto
+)
+
+// genders lists the species of the datasets whose gender is not mixed; the
+// datasets do not record gender ratios. Undiscovered species are left out
+// since they cannot breed anyway.
+var genders = map[string]Gender{
+	"nidoran♂":   GenderMale,
+	"nidorino":   GenderMale,
+	"nidoking":   GenderMale,
+	"hitmonlee":  GenderMale,
+	"hitmonchan": GenderMale,
+	"hitmontop":  GenderMale,
+	"tauros":     GenderMale,
+	"nidoran♀":   GenderFemale,
+	"chansey":    GenderFemale,
+	"blissey":    GenderFemale,
+	"kangaskhan": GenderFemale,
+	"jynx":       GenderFemale,
+	"miltank":    GenderFemale,
+	"magnemite":  GenderGenderless,
+	"magneton":   GenderGenderless,
+	"voltorb":    GenderGenderless,
+	"electrode":  GenderGenderless,
+	"staryu":     GenderGenderless,
+	"starmie":    GenderGenderless,
+	"porygon":    GenderGenderless,
+	"porygon2":   GenderGenderless,
+	"ditto":      GenderGenderless,
+}
+
+// Member identifies a Pokemon in a group or a list of partners.
+type Member struct {
+	DexNumber string `json:"dex_number"`
+	Name      string `json:"name"`
+}
+
+// Group is an egg group and the Pokemon in it.
+type Group struct {
+	Name      string   `json:"name"`
+	Breedable bool     `json:"breedable" doc:"False for the Undiscovered group"`
+	Members   []Member `json:"members" doc:"In dex order"`
+}
+
+// Partner is a Pokemon that can breed with the requested one.
+type Partner struct {
+	Member
+	Via []string `json:"via" doc:"The shared egg groups, or Ditto"`
+}
+
+// Compatibility lists the breeding partners of one Pokemon.
+type Compatibility struct {
+	Pokemon   Member    `json:"pokemon"`
+	EggGroups []string  `json:"egg_groups"`
+	Gender    Gender    `json:"gender" doc:"mixed, male, female or genderless"`
+	Partners  []Partner `json:"partners" doc:"In dex order"`
+	// Reason explains an empty Partners.
+	Reason string `json:"reason,omitempty" doc:"Why the Pokemon cannot breed, when it cannot"`
+}
+
+// EggGroups returns the egg groups of p, with Undiscovered standing in for
+// the empty groups of legendaries.
+func EggGroups(p models.Pokemon) []string {
+	groups := p.EggGroups()
+	if len(groups) == 0 {
+		return []string{Undiscovered}
+	}
+	return groups
+}
+
+// GenderOf returns the gender distribution of p's species.
+func GenderOf(p models.Pokemon) Gender {
+	if g, ok := genders[strings.ToLower(strings.TrimSpace(p.Name))]; ok {
+		return g
+	}
+	return GenderMixed
+}
+
+// CanBreed reports whether a and b can produce an egg together and, if so,
+// through which groups: the egg groups they share, or Ditto.
+func CanBreed(a, b models.Pokemon) ([]string, bool) {
+	ga, gb := EggGroups(a), EggGroups(b)
+	if slices.Contains(ga, Undiscovered) || slices.Contains(gb, Undiscovered) {
+		return nil, false
+	}
+	aDitto, bDitto := slices.Contains(ga, Ditto), slices.Contains(gb, Ditto)
+	switch {
+	case aDitto && bDitto:
+		return nil, false
+	case aDitto || bDitto:
+		return []string{Ditto}, true
+	}
+
+	genderA, genderB := GenderOf(a), GenderOf(b)
+	if genderA == GenderGenderless || genderB == GenderGenderless {
+		return nil, false
+	}
+	if genderA != GenderMixed && genderA == genderB {
+		return nil, false
+	}
+
+	var shared []string
+	for _, g := range ga {
+		if slices.Contains(gb, g) {
+			shared = append(shared, g)
+		}
+	}
+	return shared, len(shared) > 0
+}
+
+// Compatible finds the partners of p in pool. p itself is included when two
+// of its species can breed.
+func Compatible(p models.Pokemon, pool []models.Pokemon) Compatibility {
+	c := Compatibility{
+		Pokemon:   member(p),
+		EggGroups: EggGroups(p),
+		Gender:    GenderOf(p),
+		Partners:  []Partner{},
+	}
+	for _, other := range sorted(pool) {
+		if via, ok := CanBreed(p, other); ok {
+			c.Partners = append(c.Partners, Partner{Member: member(other), Via: via})
+		}
+	}
+	if len(c.Partners) == 0 {
+		switch {
+		case slices.Contains(c.EggGroups, Undiscovered):
+			c.Reason = "Pokemon in the Undiscovered egg group cannot breed"
+		case c.Gender == GenderGenderless:
+			c.Reason = "Genderless Pokemon breed only with Ditto, which is not in the dataset"
+		default:
+			c.Reason = "No Pokemon in the dataset shares an egg group"
+		}
+	}
+	return c
+}
+
+// Groups lists every egg group found in pokemons with its members, groups
+// sorted by name.
+func Groups(pokemons []models.Pokemon) []Group {
+	byName := map[string]*Group{}
+	for _, p := range sorted(pokemons) {
+		for _, name := range EggGroups(p) {
+			g, ok := byName[name]
+			if !ok {
+				g = &Group{Name: name, Breedable: name != Undiscovered, Members: []Member{}}
+				byName[name] = g
+			}
+			g.Members = append(g.Members, member(p))
+		}
+	}
+
+	out := make([]Group, 0, len(byName))
+	for _, g := range byName {
+		out = append(out, *g)
+	}
+	slices.SortFunc(out, func(a, b Group) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+func member(p models.Pokemon) Member {
+	return Member{DexNumber: p.DexNumber, Name: p.Name}
+}
+
+// sorted returns a copy of pokemons in dex order.
+func sorted(pokemons []models.Pokemon) []models.Pokemon {
+	out := slices.Clone(pokemons)
+	slices.SortStableFunc(out, func(a, b models.Pokemon) int { return a.Number() - b.Number() })
+	return out
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[string, int](2, 0)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if _, ok := c.Get("a"); !ok { // a is now the most recently used
+		t.Fatal("a missing before the cache was full")
+	}
+	c.Add("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b was kept, want it evicted as the least recently used")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := c.Get(key); !ok || got != want {
+			t.Errorf("Get(%q) = %d, %v, want %d", key, got, ok, want)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len = %d, want 2", c.Len())
+	}
+
+	// Re-adding a key updates it in place.
+	c.Add("a", 10)
+	if got, _ := c.Get("a"); got != 10 || c.Len() != 2 {
+		t.Errorf("after re-adding a: Get = %d, Len = %d, want 10 and 2", got, c.Len())
+	}
+}
+
+func TestLRUExpiresAfterTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU[string, int](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Add("a", 1)
+	now = now.Add(30 * time.Second)
+	c.Add("b", 2)
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("b"); !ok {
+		t.Error("b expired exactly at its TTL, want it kept until after")
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("a was returned 90s after it was added, want it expired")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len = %d, want the expired entry removed", c.Len())
+	}
+
+	// Adding again restarts the TTL.
+	c.Add("b", 3)
+	now = now.Add(59 * time.Second)
+	if got, ok := c.Get("b"); !ok || got != 3 {
+		t.Errorf("Get(b) = %d, %v, want 3 after it was re-added", got, ok)
+	}
+}
+
+func TestLRUZeroTTLNeverExpires(t *testing.T) {
+	now := time.Now()
+	c := NewLRU[string, int](1, 0)
+	c.now = func() time.Time { return now }
+	c.Add("a", 1)
+	now = now.Add(24 * 365 * time.Hour)
+	if _, ok := c.Get("a"); !ok {
+		t.Error("entry expired with a zero TTL")
+	}
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Options configures a caching Repository.
+type Options struct {
+	Size int           // maximum number of cached results
+	TTL  time.Duration // lifetime of a cached result
+}
+
+// Repository caches the results of a PokemonRepository. Concurrent identical
+// misses are coalesced into a single call to the wrapped repository. Errors
+// are never cached.
+type Repository struct {
+	inner repository.PokemonRepository
+	lru   *LRU[string, any]
+	group singleflight.Group
+
+	// generation is bumped by Invalidate so that results loaded before an
+	// invalidation are neither stored nor shared with later callers.
+	generation atomic.Uint64
+
+	// version is the dataset version last seen by Watch, nil until then.
+	version atomic.Pointer[repository.DatasetVersion]
+}
+
+var _ repository.PokemonRepository = (*Repository)(nil)
+
+// NewRepository wraps inner with an LRU cache.
+func NewRepository(inner repository.PokemonRepository, opts Options) *Repository {
+	return &Repository{inner: inner, lru: NewLRU[string, any](opts.Size, opts.TTL)}
+}
+
+// Invalidate drops every cached result. Call it whenever the underlying data
+// changes.
+func (r *Repository) Invalidate() {
+	r.generation.Add(1)
+	r.lru.Purge()
+}
+
+// Watch polls the dataset version every interval and invalidates the cache
+// when it changes, e.g. after the importer ran. It returns when ctx is done.
+func (r *Repository) Watch(ctx context.Context, interval time.Duration, l *slog.Logger) {
+	last, err := r.inner.Version(ctx)
+	if err != nil {
+		l.Warn("reading dataset version failed", "error", err)
+	} else {
+		// Store a copy: last is overwritten when the version changes.
+		v := last
+		r.version.Store(&v)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		v, err := r.inner.Version(ctx)
+		if err != nil {
+			l.Warn("reading dataset version failed", "error", err)
+			continue
+		}
+		if v.Version != last.Version || !v.UpdatedAt.Equal(last.UpdatedAt) {
+			l.Info("dataset version changed, invalidating cache", "from", last.Version, "to", v.Version)
+			last = v
+			r.Invalidate()
+		}
+		r.version.Store(&v)
+	}
+}
+
+func (r *Repository) List(ctx context.Context) ([]models.Pokemon, error) {
+	return load(ctx, r, "list", r.inner.List, slices.Clone)
+}
+
+func (r *Repository) GetByDexNumber(ctx context.Context, number int) (models.Pokemon, error) {
+	return load(ctx, r, "dex:"+strconv.Itoa(number), func(ctx context.Context) (models.Pokemon, error) {
+		return r.inner.GetByDexNumber(ctx, number)
+	}, identity)
+}
+
+func (r *Repository) GetByDexNumbers(ctx context.Context, numbers []int) ([]models.Pokemon, error) {
+	key := make([]string, len(numbers))
+	for i, n := range numbers {
+		key[i] = strconv.Itoa(n)
+	}
+	return load(ctx, r, "dexes:"+strings.Join(key, ","), func(ctx context.Context) ([]models.Pokemon, error) {
+		return r.inner.GetByDexNumbers(ctx, numbers)
+	}, slices.Clone)
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (models.Pokemon, error) {
+	return load(ctx, r, "name:"+normalize(name), func(ctx context.Context) (models.Pokemon, error) {
+		return r.inner.GetByName(ctx, name)
+	}, identity)
+}
+
+func (r *Repository) Lookup(ctx context.Context, ids []string) (repository.LookupResult, error) {
+	// Keyed on the identifiers as given: Missing echoes them verbatim.
+	return load(ctx, r, fmt.Sprintf("lookup:%q", ids), func(ctx context.Context) (repository.LookupResult, error) {
+		return r.inner.Lookup(ctx, ids)
+	}, func(res repository.LookupResult) repository.LookupResult {
+		res.Found = slices.Clone(res.Found)
+		res.Missing = slices.Clone(res.Missing)
+		return res
+	})
+}
+
+func (r *Repository) Search(ctx context.Context, filter repository.SearchFilter) ([]models.Pokemon, error) {
+	return load(ctx, r, searchKey(filter), func(ctx context.Context) ([]models.Pokemon, error) {
+		return r.inner.Search(ctx, filter)
+	}, slices.Clone)
+}
+
+func (r *Repository) Types(ctx context.Context) ([]string, error) {
+	return load(ctx, r, "types", r.inner.Types, slices.Clone)
+}
+
+func (r *Repository) Legendary(ctx context.Context) ([]models.Pokemon, error) {
+	return load(ctx, r, "legendary", r.inner.Legendary, slices.Clone)
+}
+
+func (r *Repository) Stats(ctx context.Context) (repository.StatsSummary, error) {
+	return load(ctx, r, "stats", r.inner.Stats, func(s repository.StatsSummary) repository.StatsSummary {
+		s.TypeDistribution = maps.Clone(s.TypeDistribution)
+		return s
+	})
+}
+
+// Version returns the version last observed by Watch, which matches the
+// cached content, and falls back to the wrapped repository before that.
+func (r *Repository) Version(ctx context.Context) (repository.DatasetVersion, error) {
+	if v := r.version.Load(); v != nil {
+		return *v, nil
+	}
+	return r.inner.Version(ctx)
+}
+
+// load returns the cached value for key or fetches it once for all concurrent
+// callers. Callers receive a copy made by clone so they cannot mutate the
+// cached value.
+func load[T any](ctx context.Context, r *Repository, key string, fetch func(context.Context) (T, error), clone func(T) T) (T, error) {
+	if v, ok := r.lru.Get(key); ok {
+		return clone(v.(T)), nil
+	}
+
+	gen := r.generation.Load()
+	v, err, _ := r.group.Do(strconv.FormatUint(gen, 10)+"|"+key, func() (any, error) {
+		// The shared call must not be cancelled by whichever caller started it.
+		v, err := fetch(context.WithoutCancel(ctx))
+		if err == nil && r.generation.Load() == gen {
+			r.lru.Add(key, v)
+		}
+		return v, err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return clone(v.(T)), nil
+}
+
+func identity[T any](v T) T { return v }
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// searchKey mirrors the matching rules of the Mongo repository: the name
+// query and type are case-insensitive, and the type ignores surrounding space.
+func searchKey(f repository.SearchFilter) string {
+	legendary := ""
+	if f.Legendary != nil {
+		legendary = strconv.FormatBool(*f.Legendary)
+	}
+	return fmt.Sprintf("search:%q|%q|%s", strings.ToLower(f.Query), normalize(f.Type), legendary)
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeRepository counts List calls and serves a version the test can change.
+type fakeRepository struct {
+	repository.PokemonRepository
+
+	calls   atomic.Int32
+	block   chan struct{} // when set, List waits for it to be closed
+	err     error
+	version atomic.Int64
+}
+
+func (f *fakeRepository) List(ctx context.Context) ([]models.Pokemon, error) {
+	f.calls.Add(1)
+	if f.block != nil {
+		<-f.block
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []models.Pokemon{{DexNumber: "#0001", Name: "Bulbasaur"}}, nil
+}
+
+func (f *fakeRepository) Version(ctx context.Context) (repository.DatasetVersion, error) {
+	return repository.DatasetVersion{Version: f.version.Load()}, nil
+}
+
+func TestRepositoryCachesCopies(t *testing.T) {
+	inner := &fakeRepository{}
+	r := NewRepository(inner, Options{Size: 10, TTL: time.Minute})
+
+	first, err := r.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0].Name = "changed"
+	second, err := r.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls.Load() != 1 {
+		t.Errorf("inner List calls = %d, want 1", inner.calls.Load())
+	}
+	if second[0].Name != "Bulbasaur" {
+		t.Errorf("cached name = %q, want the caller's change not to reach the cache", second[0].Name)
+	}
+
+	r.Invalidate()
+	if _, err := r.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls.Load() != 2 {
+		t.Errorf("inner List calls after Invalidate = %d, want 2", inner.calls.Load())
+	}
+}
+
+func TestRepositoryDoesNotCacheErrors(t *testing.T) {
+	inner := &fakeRepository{err: errors.New("connection reset")}
+	r := NewRepository(inner, Options{Size: 10})
+	for range 2 {
+		if _, err := r.List(context.Background()); err == nil {
+			t.Fatal("List succeeded, want the inner error")
+		}
+	}
+	if inner.calls.Load() != 2 {
+		t.Errorf("inner List calls = %d, want 2", inner.calls.Load())
+	}
+}
+
+func TestRepositoryCollapsesConcurrentMisses(t *testing.T) {
+	inner := &fakeRepository{block: make(chan struct{})}
+	r := NewRepository(inner, Options{Size: 10, TTL: time.Minute})
+
+	const callers = 20
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	errs := make(chan error, callers)
+	for range callers {
+		go func() {
+			defer done.Done()
+			started.Done()
+			_, err := r.List(context.Background())
+			errs <- err
+		}()
+	}
+	started.Wait()
+	// Give every caller time to reach the in-flight call; without
+	// coalescing each of them would call the inner repository.
+	time.Sleep(20 * time.Millisecond)
+	close(inner.block)
+	done.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := inner.calls.Load(); got != 1 {
+		t.Errorf("inner List calls = %d, want 1", got)
+	}
+}
+
+func TestWatchInvalidatesOnVersionChange(t *testing.T) {
+	inner := &fakeRepository{}
+	inner.version.Store(1)
+	r := NewRepository(inner, Options{Size: 10, TTL: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	version := func() int64 {
+		v, _ := r.Version(context.Background())
+		return v.Version
+	}
+	waitFor("Watch to read the version", func() bool { return r.version.Load() != nil })
+
+	for range 2 {
+		if _, err := r.List(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.calls.Load() != 1 {
+		t.Fatalf("inner List calls = %d, want 1 before the version changes", inner.calls.Load())
+	}
+
+	inner.version.Store(2)
+	waitFor("Version to report 2", func() bool { return version() == 2 })
+	if _, err := r.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls.Load() != 2 {
+		t.Errorf("inner List calls = %d, want 2 after the version changed", inner.calls.Load())
+	}
+}
@@ -0,0 +1,171 @@
+// Package client is a typed Go client for the Pokedex API. It depends only on
+// the models package, so other services can import it without pulling in the
+// server.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBackoff    = 200 * time.Millisecond
+	maxBackoff        = 10 * time.Second
+)
+
+// Client calls the Pokedex API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	http       *http.Client
+	userAgent  string
+	maxRetries int
+	backoff    time.Duration
+}
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client; the default is a client
+// with a 30 second timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithRetry sets how many times a failed request is retried and the initial
+// backoff, which doubles on every attempt. Zero retries disables retrying.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New returns a client for the API served at baseURL, e.g.
+// "http://pokedex:8080". The /api prefix is added by the client.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("client: base URL must be http or https, got %q", baseURL)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+
+	c := &Client{
+		baseURL:    u,
+		http:       &http.Client{Timeout: 30 * time.Second},
+		userAgent:  "pokedex-go-client",
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// get fetches path (relative to /api) and decodes the JSON body into out.
+// Network errors and 429/502/503/504 responses are retried with exponential
+// backoff and jitter, honouring Retry-After.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) (http.Header, error) {
+	// path is already escaped, e.g. a name passed through url.PathEscape.
+	u := c.baseURL.JoinPath("/api" + path)
+	u.RawQuery = query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		header, retryAfter, err := c.once(ctx, u.String(), out)
+		if err == nil || attempt >= c.maxRetries || !retryable(err) {
+			return header, err
+		}
+		wait := c.backoffFor(attempt)
+		if retryAfter > 0 {
+			wait = min(retryAfter, maxBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *Client) once(ctx context.Context, rawURL string, out any) (http.Header, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.Header, parseRetryAfter(resp.Header.Get("Retry-After")), decodeError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, 0, fmt.Errorf("client: decode %s: %w", req.URL.Path, err)
+	}
+	return resp.Header, 0, nil
+}
+
+// backoffFor returns a delay in [d/2, d) where d doubles with every attempt.
+func (c *Client) backoffFor(attempt int) time.Duration {
+	d := min(c.backoff<<attempt, maxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Status {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// parseRetryAfter accepts both forms of the header: seconds or an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"GO-Mongo/api"
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRouter returns the real API router over the Kanto dataset.
+func newRouter(t *testing.T) http.Handler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r, err := api.NewRouter(api.Options{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Pokemon: repository.NewMemory(fixture.Dataset(t, "kanto")),
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+	return r
+}
+
+func newClient(t *testing.T, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, append([]Option{WithRetry(3, time.Millisecond)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestGetPokemon(t *testing.T) {
+	c := newClient(t, newRouter(t))
+	ctx := context.Background()
+
+	p, err := c.GetPokemon(ctx, 25)
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if p.Name != "Pikachu" {
+		t.Errorf("GetPokemon(25) = %q, want Pikachu", p.Name)
+	}
+
+	p, err = c.GetPokemonByName(ctx, "mr. mime")
+	if err != nil {
+		t.Fatalf("GetPokemonByName: %v", err)
+	}
+	if p.DexNumber != "#0122" {
+		t.Errorf("GetPokemonByName(mr. mime) = %s, want #0122", p.DexNumber)
+	}
+}
+
+func TestGetPokemonBatch(t *testing.T) {
+	c := newClient(t, newRouter(t))
+
+	found, notFound, err := c.GetPokemonBatch(context.Background(), "7", "charmander", "#0001", "missingno", "7")
+	if err != nil {
+		t.Fatalf("GetPokemonBatch: %v", err)
+	}
+	var names []string
+	for _, p := range found {
+		names = append(names, p.Name)
+	}
+	if want := []string{"Squirtle", "Charmander", "Bulbasaur", "Squirtle"}; !slices.Equal(names, want) {
+		t.Errorf("found %v, want %v", names, want)
+	}
+	if !slices.Equal(notFound, []string{"missingno"}) {
+		t.Errorf("notFound = %v, want [missingno]", notFound)
+	}
+
+	_, _, err = c.GetPokemonBatch(context.Background(), "0")
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetPokemonBatch(0) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestErrorsUnwrapProblem(t *testing.T) {
+	c := newClient(t, newRouter(t))
+	ctx := context.Background()
+
+	_, err := c.GetPokemon(ctx, 9999)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetPokemon(9999) error = %v, want ErrNotFound", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error %T is not *APIError", err)
+	}
+	if apiErr.Status != http.StatusNotFound || apiErr.Code != "not_found" || apiErr.RequestID == "" {
+		t.Errorf("APIError = %+v, want 404 not_found with a request id", apiErr)
+	}
+
+	_, err = c.GetPokemon(ctx, 0)
+	if !errors.Is(err, ErrInvalidInput) || !errors.As(err, &apiErr) || len(apiErr.Details) == 0 || apiErr.Details[0].Field != "id" {
+		t.Errorf("GetPokemon(0) error = %#v, want invalid input on field id", err)
+	}
+}
+
+func TestSearchTypesStats(t *testing.T) {
+	c := newClient(t, newRouter(t))
+	ctx := context.Background()
+
+	no := false
+	found, err := c.Search(ctx, SearchFilter{Type: "psychic", Legendary: &no})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(found) == 0 {
+		t.Fatal("Search returned nothing for non-legendary Psychic")
+	}
+	for _, p := range found {
+		if p.IsLegendary != "False" {
+			t.Errorf("Search returned legendary %s", p.Name)
+		}
+	}
+
+	types, err := c.Types(ctx)
+	if err != nil {
+		t.Fatalf("Types: %v", err)
+	}
+	if len(types) != 17 {
+		t.Errorf("Types returned %d types, want the 17 present in the Kanto dataset: %v", len(types), types)
+	}
+
+	stats, err := c.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.TotalPokemon != 151 {
+		t.Errorf("Stats.TotalPokemon = %d, want 151", stats.TotalPokemon)
+	}
+
+	legendary, err := c.Legendary(ctx)
+	if err != nil {
+		t.Fatalf("Legendary: %v", err)
+	}
+	if int64(len(legendary)) != stats.LegendaryCount {
+		t.Errorf("Legendary returned %d, Stats says %d", len(legendary), stats.LegendaryCount)
+	}
+}
+
+func TestListIter(t *testing.T) {
+	var requests atomic.Int32
+	router := newRouter(t)
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		router.ServeHTTP(w, r)
+	}))
+	ctx := context.Background()
+
+	var names []string
+	for p, err := range c.ListIter(ctx, 40) {
+		if err != nil {
+			t.Fatalf("ListIter: %v", err)
+		}
+		names = append(names, p.Name)
+	}
+	if len(names) != 151 || names[0] != "Bulbasaur" || names[150] != "Mew" {
+		t.Errorf("ListIter yielded %d Pokemon from %q to %q", len(names), names[0], names[len(names)-1])
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("ListIter made %d requests, want 4 pages of 40", got)
+	}
+
+	// Breaking out early must not fetch further pages.
+	requests.Store(0)
+	for range c.SearchIter(ctx, SearchFilter{Type: "Water"}, 5) {
+		break
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("early break made %d requests, want 1", got)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	router := newRouter(t)
+	var calls atomic.Int32
+	flaky := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, `{"status":503,"code":"unavailable","detail":"database unavailable"}`)
+			return
+		}
+		router.ServeHTTP(w, r)
+	})
+
+	c := newClient(t, flaky)
+	if _, err := c.GetPokemon(context.Background(), 1); err != nil {
+		t.Fatalf("GetPokemon after two 503s: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("made %d calls, want 3", got)
+	}
+
+	calls.Store(0)
+	c = newClient(t, flaky, WithRetry(1, time.Millisecond))
+	_, err := c.GetPokemon(context.Background(), 1)
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("error = %v, want ErrUnavailable once retries run out", err)
+	}
+
+	// Client errors are not retried.
+	calls.Store(10)
+	_, _ = c.GetPokemon(context.Background(), 0)
+	if got := calls.Load(); got != 11 {
+		t.Errorf("400 was retried: %d calls", got-10)
+	}
+}
+
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Types(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("waited %v despite the context deadline", time.Since(start))
+	}
+}
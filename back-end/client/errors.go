@@ -0,0 +1,87 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Error kinds matched with errors.Is against the errors returned by Client.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrTimeout      = errors.New("timeout")
+	ErrUnavailable  = errors.New("unavailable")
+)
+
+// Detail is one field-level problem reported with an invalid input error.
+type Detail struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// APIError is a non-2xx response. The fields come from the server's
+// application/problem+json body when one is present.
+type APIError struct {
+	Status    int      `json:"status"`
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Code      string   `json:"code"`
+	Detail    string   `json:"detail"`
+	Instance  string   `json:"instance"`
+	Details   []Detail `json:"details"`
+	RequestID string   `json:"request_id"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if msg == "" {
+		msg = http.StatusText(e.Status)
+	}
+	s := fmt.Sprintf("pokedex api: %d %s", e.Status, msg)
+	if e.RequestID != "" {
+		s += " (request " + e.RequestID + ")"
+	}
+	return s
+}
+
+// Is maps the problem code, or the status when the body had none, onto the
+// package's error kinds.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == "not_found" || (e.Code == "" && e.Status == http.StatusNotFound)
+	case ErrInvalidInput:
+		return e.Code == "invalid_input" || (e.Code == "" && e.Status == http.StatusBadRequest)
+	case ErrTimeout:
+		return e.Code == "timeout" || (e.Code == "" && e.Status == http.StatusGatewayTimeout)
+	case ErrUnavailable:
+		return e.Code == "unavailable" || (e.Code == "" && e.Status == http.StatusServiceUnavailable)
+	}
+	return false
+}
+
+// decodeError builds an APIError from resp, falling back to the status line
+// when the body is not a problem document.
+func decodeError(resp *http.Response) error {
+	e := &APIError{}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	ct := resp.Header.Get("Content-Type")
+	if strings.Contains(ct, "json") {
+		_ = json.Unmarshal(body, e)
+	}
+	e.Status = resp.StatusCode
+	if e.Title == "" {
+		e.Title = http.StatusText(resp.StatusCode)
+	}
+	if e.RequestID == "" {
+		e.RequestID = resp.Header.Get("X-Request-ID")
+	}
+	return e
+}
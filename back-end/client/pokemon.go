@@ -0,0 +1,168 @@
+package client
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is used by the iterators when pageSize is not positive.
+// Larger sizes are capped at MaxPageSize, the server's limit.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// SearchFilter narrows Search results. Zero values mean "no constraint".
+type SearchFilter struct {
+	Query     string // substring of the name or dex number
+	Type      string // matches either type slot, e.g. "Fire"
+	Legendary *bool
+}
+
+func (f SearchFilter) values() url.Values {
+	q := url.Values{}
+	if f.Query != "" {
+		q.Set("q", f.Query)
+	}
+	if f.Type != "" {
+		q.Set("type", f.Type)
+	}
+	if f.Legendary != nil {
+		q.Set("legendary", strconv.FormatBool(*f.Legendary))
+	}
+	return q
+}
+
+// StatsSummary is returned by Stats.
+type StatsSummary struct {
+	TotalPokemon     int64          `json:"totalPokemon"`
+	LegendaryCount   int64          `json:"legendaryCount"`
+	TypeDistribution map[string]int `json:"typeDistribution"`
+}
+
+// List returns every Pokemon. Use ListIter to fetch them page by page.
+func (c *Client) List(ctx context.Context) ([]models.Pokemon, error) {
+	var out []models.Pokemon
+	_, err := c.get(ctx, "/pokemon", nil, &out)
+	return out, err
+}
+
+// GetPokemon returns the Pokemon with the given national dex number.
+func (c *Client) GetPokemon(ctx context.Context, id int) (models.Pokemon, error) {
+	var out models.Pokemon
+	_, err := c.get(ctx, "/pokemon/"+strconv.Itoa(id), nil, &out)
+	return out, err
+}
+
+// GetPokemonByName looks a Pokemon up by its exact, case-insensitive name.
+func (c *Client) GetPokemonByName(ctx context.Context, name string) (models.Pokemon, error) {
+	var out models.Pokemon
+	_, err := c.get(ctx, "/pokemon/name/"+url.PathEscape(name), nil, &out)
+	return out, err
+}
+
+// GetPokemonBatch looks up several Pokemon in one request. Each id is a dex
+// number or a name; found keeps the request order and notFound lists the ids
+// that matched nothing.
+func (c *Client) GetPokemonBatch(ctx context.Context, ids ...string) (found []models.Pokemon, notFound []string, err error) {
+	var out struct {
+		Pokemon  []models.Pokemon `json:"pokemon"`
+		NotFound []string         `json:"not_found"`
+	}
+	_, err = c.get(ctx, "/pokemon/batch", url.Values{"ids": {strings.Join(ids, ",")}}, &out)
+	return out.Pokemon, out.NotFound, err
+}
+
+// Search returns the Pokemon matching filter.
+func (c *Client) Search(ctx context.Context, filter SearchFilter) ([]models.Pokemon, error) {
+	var out []models.Pokemon
+	_, err := c.get(ctx, "/pokemon/search", filter.values(), &out)
+	return out, err
+}
+
+// Types returns the type names present in the dataset.
+func (c *Client) Types(ctx context.Context) ([]string, error) {
+	var out []string
+	_, err := c.get(ctx, "/pokemon/types", nil, &out)
+	return out, err
+}
+
+// Legendary returns every legendary Pokemon.
+func (c *Client) Legendary(ctx context.Context) ([]models.Pokemon, error) {
+	var out []models.Pokemon
+	_, err := c.get(ctx, "/pokemon/legendary", nil, &out)
+	return out, err
+}
+
+// Stats returns summary counts over the dataset.
+func (c *Client) Stats(ctx context.Context) (StatsSummary, error) {
+	var out StatsSummary
+	_, err := c.get(ctx, "/pokemon/stats", nil, &out)
+	return out, err
+}
+
+// ListIter yields every Pokemon, fetching pageSize at a time. Iteration stops
+// after the first error, which is yielded with a zero Pokemon.
+func (c *Client) ListIter(ctx context.Context, pageSize int) iter.Seq2[models.Pokemon, error] {
+	return c.pages(ctx, "/pokemon", nil, pageSize)
+}
+
+// SearchIter is Search as a paginated iterator.
+func (c *Client) SearchIter(ctx context.Context, filter SearchFilter, pageSize int) iter.Seq2[models.Pokemon, error] {
+	return c.pages(ctx, "/pokemon/search", filter.values(), pageSize)
+}
+
+// LegendaryIter is Legendary as a paginated iterator.
+func (c *Client) LegendaryIter(ctx context.Context, pageSize int) iter.Seq2[models.Pokemon, error] {
+	return c.pages(ctx, "/pokemon/legendary", nil, pageSize)
+}
+
+// pages walks a list route with limit/offset until X-Total-Count items have
+// been seen or a page comes back with other than pageSize items, which also
+// stops a server that ignores limit from being re-read forever.
+func (c *Client) pages(ctx context.Context, path string, query url.Values, pageSize int) iter.Seq2[models.Pokemon, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+	return func(yield func(models.Pokemon, error) bool) {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("limit", strconv.Itoa(pageSize))
+
+		for offset := 0; ; {
+			q.Set("offset", strconv.Itoa(offset))
+			var page []models.Pokemon
+			header, err := c.get(ctx, path, q, &page)
+			if err != nil {
+				yield(models.Pokemon{}, err)
+				return
+			}
+			for _, p := range page {
+				if !yield(p, nil) {
+					return
+				}
+			}
+			offset += len(page)
+
+			total, err := strconv.Atoi(strings.TrimSpace(header.Get("X-Total-Count")))
+			if err != nil {
+				if header.Get("X-Total-Count") != "" {
+					yield(models.Pokemon{}, fmt.Errorf("client: invalid X-Total-Count: %w", err))
+					return
+				}
+				total = -1
+			}
+			if len(page) != pageSize || (total >= 0 && offset >= total) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package compare lines up the base stats and type matchups of a few
+// Pokemon side by side, so every client shows the same winners and ranking.
+package compare
+
+import (
+	"GO-Mongo/models"
+	"GO-Mongo/typechart"
+	"slices"
+)
+
+// Limits on the number of Pokemon compared at once.
+const (
+	MinPokemon = 2
+	MaxPokemon = 6
+)
+
+// Entry is one compared Pokemon.
+type Entry struct {
+	DexNumber string           `json:"dex_number"`
+	Name      string           `json:"name"`
+	Types     []string         `json:"types"`
+	BaseStats models.BaseStats `json:"base_stats"`
+}
+
+// StatRow compares one stat. Values and Deltas are aligned with
+// Comparison.Pokemon.
+type StatRow struct {
+	Stat   string `json:"stat"`
+	Values []int  `json:"values"`
+	Deltas []int  `json:"deltas" doc:"Value minus the best value of the stat"`
+	// Winners are the names with the best value; several on a tie.
+	Winners []string `json:"winners"`
+}
+
+// Hit is the strongest attack one Pokemon has against another from its own
+// types (STAB).
+type Hit struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Matchup compares the STAB hits two Pokemon have against each other.
+type Matchup struct {
+	A      string `json:"a"`
+	B      string `json:"b"`
+	AToB   Hit    `json:"a_to_b"`
+	BToA   Hit    `json:"b_to_a"`
+	Winner string `json:"winner" doc:"Name of the Pokemon with the stronger hit; empty when even"`
+}
+
+// Rank places one Pokemon in the overall ranking.
+type Rank struct {
+	Rank          int    `json:"rank"`
+	DexNumber     string `json:"dex_number"`
+	Name          string `json:"name"`
+	StatsWon      int    `json:"stats_won" doc:"Stats (of the six) with the best value, ties included"`
+	BaseStatTotal int    `json:"base_stat_total"`
+	MatchupsWon   int    `json:"matchups_won"`
+}
+
+// Comparison is the result of Compare.
+type Comparison struct {
+	Pokemon  []Entry   `json:"pokemon"`
+	Stats    []StatRow `json:"stats" doc:"The six stats, then the base stat total"`
+	Matchups []Matchup `json:"matchups" doc:"Every pair, in input order"`
+	Ranking  []Rank    `json:"ranking" doc:"By stats won, then base stat total, then matchups won"`
+}
+
+var statNames = []string{"hp", "attack", "defense", "sp_attack", "sp_defense", "speed", "total"}
+
+func statValues(b models.BaseStats) []int {
+	return []int{b.HP, b.Attack, b.Defense, b.SpAttack, b.SpDefense, b.Speed, b.Total}
+}
+
+// Compare evaluates pokemons with chart. Types the chart lacks are ignored.
+func Compare(chart *typechart.Chart, pokemons []models.Pokemon) Comparison {
+	c := Comparison{Pokemon: make([]Entry, len(pokemons)), Matchups: []Matchup{}}
+	for i, p := range pokemons {
+		c.Pokemon[i] = Entry{DexNumber: p.DexNumber, Name: p.Name, Types: chartTypes(chart, p), BaseStats: p.BaseStats()}
+	}
+
+	ranks := make([]Rank, len(c.Pokemon))
+	for i, e := range c.Pokemon {
+		ranks[i] = Rank{DexNumber: e.DexNumber, Name: e.Name, BaseStatTotal: e.BaseStats.Total}
+	}
+
+	for s, stat := range statNames {
+		row := StatRow{Stat: stat, Values: make([]int, len(c.Pokemon)), Deltas: make([]int, len(c.Pokemon)), Winners: []string{}}
+		for i, e := range c.Pokemon {
+			row.Values[i] = statValues(e.BaseStats)[s]
+		}
+		best := slices.Max(row.Values)
+		for i, v := range row.Values {
+			row.Deltas[i] = v - best
+			if v == best {
+				row.Winners = append(row.Winners, c.Pokemon[i].Name)
+				if stat != "total" {
+					ranks[i].StatsWon++
+				}
+			}
+		}
+		c.Stats = append(c.Stats, row)
+	}
+
+	for i := range c.Pokemon {
+		for j := i + 1; j < len(c.Pokemon); j++ {
+			a, b := c.Pokemon[i], c.Pokemon[j]
+			m := Matchup{A: a.Name, B: b.Name, AToB: bestHit(chart, a, b), BToA: bestHit(chart, b, a)}
+			switch {
+			case m.AToB.Multiplier > m.BToA.Multiplier:
+				m.Winner = a.Name
+				ranks[i].MatchupsWon++
+			case m.BToA.Multiplier > m.AToB.Multiplier:
+				m.Winner = b.Name
+				ranks[j].MatchupsWon++
+			}
+			c.Matchups = append(c.Matchups, m)
+		}
+	}
+
+	slices.SortStableFunc(ranks, func(x, y Rank) int {
+		switch {
+		case x.StatsWon != y.StatsWon:
+			return y.StatsWon - x.StatsWon
+		case x.BaseStatTotal != y.BaseStatTotal:
+			return y.BaseStatTotal - x.BaseStatTotal
+		}
+		return y.MatchupsWon - x.MatchupsWon
+	})
+	for i := range ranks {
+		ranks[i].Rank = i + 1
+	}
+	c.Ranking = ranks
+	return c
+}
+
+// bestHit is attacker's most effective own type against defender. A
+// Pokemon without a type in the chart hits for 1x.
+func bestHit(chart *typechart.Chart, attacker, defender Entry) Hit {
+	best := Hit{Multiplier: 1}
+	for i, t := range attacker.Types {
+		x, _ := chart.Effectiveness(t, defender.Types...)
+		if i == 0 || x > best.Multiplier {
+			best = Hit{Type: t, Multiplier: x}
+		}
+	}
+	return best
+}
+
+func chartTypes(chart *typechart.Chart, p models.Pokemon) []string {
+	types := []string{}
+	for _, t := range p.Types() {
+		if canonical, ok := chart.Canonical(t); ok {
+			types = append(types, canonical)
+		}
+	}
+	return types
+}
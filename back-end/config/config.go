@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3" // นำเข้า package yaml สำหรับอ่านไฟล์ YAML
 )
@@ -15,8 +18,93 @@ type MongoConnect struct {
 	Collection string `yaml:"collection"` // ชื่อ collection ที่ต้องการเชื่อมต่อ
 }
 
+// URI builds the connection string used by the Mongo driver. It contains the
+// password in clear text and must never be logged; use RedactedURI instead.
+func (m MongoConnect) URI() string {
+	return fmt.Sprintf("mongodb://%s:%s@%s:%d", m.User, m.Pass, m.Host, m.Port)
+}
+
+// RedactedURI is URI with the password masked, safe to print in logs.
+func (m MongoConnect) RedactedURI() string {
+	return fmt.Sprintf("mongodb://%s:%s@%s:%d", m.User, "xxxxx", m.Host, m.Port)
+}
+
+// LogValue implements slog.LogValuer so that logging the struct directly
+// never leaks the password.
+func (m MongoConnect) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("uri", m.RedactedURI()),
+		slog.String("database", m.Database),
+		slog.String("collection", m.Collection),
+	)
+}
+
+type Logging struct {
+	Level  string `yaml:"level"`  // ระดับของ log: debug, info, warn, error
+	Format string `yaml:"format"` // รูปแบบของ log: json หรือ text (ถ้าไม่กำหนด production ใช้ json, อื่นๆ ใช้ text)
+}
+
+type Cache struct {
+	Disabled    bool          `yaml:"disabled"`     // ปิดการใช้ cache ของ repository
+	Size        int           `yaml:"size"`         // จำนวนผลลัพธ์สูงสุดที่เก็บใน cache
+	TTL         time.Duration `yaml:"ttl"`          // อายุของผลลัพธ์แต่ละรายการ เช่น 10m
+	VersionPoll time.Duration `yaml:"version_poll"` // ความถี่ในการตรวจ dataset version เพื่อล้าง cache หลัง import
+}
+
+type HTTPCache struct {
+	Default string            `yaml:"default"` // ค่า Cache-Control เริ่มต้นของ GET response
+	Routes  map[string]string `yaml:"routes"`  // ค่า Cache-Control แยกตาม route pattern ของ Gin เช่น /api/pokemon/:id
+}
+
+type CORS struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`   // origin ที่อนุญาต รองรับ wildcard subdomain เช่น https://*.example.com
+	AllowedMethods   []string      `yaml:"allowed_methods"`   // HTTP method ที่อนุญาต
+	AllowedHeaders   []string      `yaml:"allowed_headers"`   // request header ที่อนุญาต
+	ExposedHeaders   []string      `yaml:"exposed_headers"`   // response header ที่ให้ JavaScript อ่านได้
+	AllowCredentials bool          `yaml:"allow_credentials"` // อนุญาตให้ส่ง cookie/Authorization ข้าม origin
+	MaxAge           time.Duration `yaml:"max_age"`           // ระยะเวลาที่ browser cache ผล preflight
+}
+
+type GRPC struct {
+	Addr string `yaml:"addr"` // ที่อยู่ที่ gRPC server รับการเชื่อมต่อ เช่น :9090 (แยกพอร์ตจาก REST API)
+}
+
 type LoginWithParam struct {
-	MongoDB MongoConnect `yaml:"mongodb"` // กำหนดโครงสร้างสำหรับการเชื่อมต่อ MongoDB
+	Environment string          `yaml:"environment"` // สภาพแวดล้อมที่รัน (development, production) ถูก override ด้วยตัวแปร GO_ENV
+	MongoDB     MongoConnect    `yaml:"mongodb"`     // กำหนดโครงสร้างสำหรับการเชื่อมต่อ MongoDB
+	Logging     Logging         `yaml:"logging"`     // กำหนดการตั้งค่า log
+	Cache       Cache           `yaml:"cache"`       // กำหนดการตั้งค่า cache ของ repository
+	HTTPCache   HTTPCache       `yaml:"http_cache"`  // กำหนด Cache-Control สำหรับ browser และ CDN
+	CORS        map[string]CORS `yaml:"cors"`        // นโยบาย CORS แยกตาม environment
+	GRPC        GRPC            `yaml:"grpc"`        // กำหนดการตั้งค่า gRPC server
+}
+
+// IsProduction reports whether the service runs with production defaults.
+func (c *LoginWithParam) IsProduction() bool {
+	return c.Environment == "production"
+}
+
+// CORSPolicy returns the CORS settings for the current environment with
+// defaults filled in. An environment without an entry allows no origins,
+// except development which allows the local frontend dev servers.
+func (c *LoginWithParam) CORSPolicy() CORS {
+	p, ok := c.CORS[c.Environment]
+	if !ok && c.Environment == "development" {
+		p.AllowedOrigins = []string{"http://localhost:5173", "http://127.0.0.1:5173", "http://localhost:4173", "http://localhost:3000", "http://localhost:30000"}
+	}
+	if len(p.AllowedMethods) == 0 {
+		p.AllowedMethods = []string{"GET", "POST", "OPTIONS"}
+	}
+	if len(p.AllowedHeaders) == 0 {
+		p.AllowedHeaders = []string{"Content-Type", "Authorization", "X-Request-ID"}
+	}
+	if len(p.ExposedHeaders) == 0 {
+		p.ExposedHeaders = []string{"X-Request-ID", "ETag", "Last-Modified", "X-Total-Count", "Link", "Deprecation", "Sunset"}
+	}
+	if p.MaxAge == 0 {
+		p.MaxAge = 10 * time.Minute
+	}
+	return p
 }
 
 func LoadConfig(path string) (*LoginWithParam, error) {
@@ -31,5 +119,41 @@ func LoadConfig(path string) (*LoginWithParam, error) {
 	if err != nil {
 		return nil, err // ถ้า decode ไม่สำเร็จ ให้คืนค่า error
 	}
+	config.applyDefaults()
 	return &config, nil // คืนค่าตัวแปร config ที่อ่านได้
 }
+
+// applyDefaults fills values that were not set in env.yaml. GO_ENV, which the
+// k8s manifests already export, takes precedence over the file.
+func (c *LoginWithParam) applyDefaults() {
+	if env := os.Getenv("GO_ENV"); env != "" {
+		c.Environment = env
+	}
+	if c.Environment == "" {
+		c.Environment = "development"
+	}
+	if c.Logging.Level == "" {
+		c.Logging.Level = "info"
+	}
+	if c.Logging.Format == "" {
+		c.Logging.Format = "text"
+		if c.IsProduction() {
+			c.Logging.Format = "json"
+		}
+	}
+	if c.Cache.Size == 0 {
+		c.Cache.Size = 512
+	}
+	if c.Cache.TTL == 0 {
+		c.Cache.TTL = 10 * time.Minute
+	}
+	if c.Cache.VersionPoll == 0 {
+		c.Cache.VersionPoll = 30 * time.Second
+	}
+	if c.HTTPCache.Default == "" {
+		c.HTTPCache.Default = "public, max-age=60"
+	}
+	if c.GRPC.Addr == "" {
+		c.GRPC.Addr = ":9090"
+	}
+}
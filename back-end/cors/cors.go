@@ -0,0 +1,185 @@
+// Package cors implements the API's Cross-Origin Resource Sharing policy.
+package cors
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Config describes which cross-origin requests are allowed.
+type Config struct {
+	// AllowedOrigins lists exact origins ("https://pokedex.example.com"),
+	// wildcard subdomains ("https://*.example.com") or "*" for any origin.
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration // how long browsers may cache a preflight
+}
+
+type policy struct {
+	anyOrigin   bool
+	exact       map[string]bool
+	wildcards   []wildcard
+	methods     []string
+	headers     map[string]bool
+	allowed     string // comma-joined AllowedMethods
+	allowHdrs   string // comma-joined AllowedHeaders
+	exposed     string
+	credentials bool
+	maxAge      string
+}
+
+// wildcard matches "scheme://<one or more labels>.suffix".
+type wildcard struct {
+	prefix string // "https://"
+	suffix string // ".example.com" or ".example.com:8443"
+}
+
+// New returns the CORS middleware for cfg. It refuses a configuration that
+// allows credentials for any origin, which browsers reject anyway and which
+// would expose authenticated endpoints to every site.
+func New(cfg Config) (gin.HandlerFunc, error) {
+	p := &policy{
+		exact:       make(map[string]bool),
+		headers:     make(map[string]bool),
+		methods:     upper(cfg.AllowedMethods),
+		exposed:     strings.Join(cfg.ExposedHeaders, ", "),
+		credentials: cfg.AllowCredentials,
+	}
+	for _, o := range cfg.AllowedOrigins {
+		o = strings.ToLower(strings.TrimRight(strings.TrimSpace(o), "/"))
+		switch {
+		case o == "*":
+			p.anyOrigin = true
+		case strings.Contains(o, "://*."):
+			scheme, rest, _ := strings.Cut(o, "://*.")
+			if rest == "" || strings.Contains(rest, "*") {
+				return nil, errors.New("cors: invalid wildcard origin " + o)
+			}
+			p.wildcards = append(p.wildcards, wildcard{prefix: scheme + "://", suffix: "." + rest})
+		case strings.Contains(o, "*"):
+			return nil, errors.New("cors: wildcards are only supported as the first subdomain label: " + o)
+		default:
+			p.exact[o] = true
+		}
+	}
+	if p.anyOrigin && p.credentials {
+		return nil, errors.New("cors: allow_credentials cannot be combined with origin *")
+	}
+	for _, h := range cfg.AllowedHeaders {
+		p.headers[strings.ToLower(strings.TrimSpace(h))] = true
+	}
+	p.allowed = strings.Join(p.methods, ", ")
+	p.allowHdrs = strings.Join(cfg.AllowedHeaders, ", ")
+	if cfg.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+	return p.handle, nil
+}
+
+func (p *policy) handle(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+	h := c.Writer.Header()
+	if !p.anyOrigin || p.credentials {
+		// The response differs per origin, so shared caches must key on it.
+		h.Add("Vary", "Origin")
+	}
+	if preflight {
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if origin == "" {
+		c.Next()
+		return
+	}
+	if !p.originAllowed(origin) {
+		if preflight {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+		return
+	}
+
+	if p.anyOrigin {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if p.exposed != "" {
+			h.Set("Access-Control-Expose-Headers", p.exposed)
+		}
+		c.Next()
+		return
+	}
+
+	if !slices.Contains(p.methods, strings.ToUpper(c.GetHeader("Access-Control-Request-Method"))) || !p.headersAllowed(c.GetHeader("Access-Control-Request-Headers")) {
+		// Without the Allow-* headers the browser fails the preflight.
+		h.Del("Access-Control-Allow-Origin")
+		h.Del("Access-Control-Allow-Credentials")
+		c.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+	h.Set("Access-Control-Allow-Methods", p.allowed)
+	if p.allowHdrs != "" {
+		h.Set("Access-Control-Allow-Headers", p.allowHdrs)
+	}
+	if p.maxAge != "" {
+		h.Set("Access-Control-Max-Age", p.maxAge)
+	}
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+func (p *policy) originAllowed(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if p.exact[origin] {
+		return true
+	}
+	for _, w := range p.wildcards {
+		if strings.HasPrefix(origin, w.prefix) && strings.HasSuffix(origin, w.suffix) &&
+			len(origin) > len(w.prefix)+len(w.suffix) {
+			label := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+			if !strings.ContainsAny(label, "/:@") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *policy) headersAllowed(requested string) bool {
+	for _, h := range strings.Split(requested, ",") {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h != "" && !p.headers[h] {
+			return false
+		}
+	}
+	return true
+}
+
+func upper(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		out = append(out, strings.ToUpper(strings.TrimSpace(s)))
+	}
+	return out
+}
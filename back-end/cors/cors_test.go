@@ -0,0 +1,175 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newEngine(t *testing.T, cfg Config) *gin.Engine {
+	t.Helper()
+	mw, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(mw)
+	r.GET("/api/pokemon", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return r
+}
+
+func request(r http.Handler, method, origin string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/pokemon", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"any origin with credentials", Config{AllowedOrigins: []string{"https://a.example.com", "*"}, AllowCredentials: true}, "allow_credentials"},
+		{"wildcard inside a label", Config{AllowedOrigins: []string{"https://pokedex-*.example.com"}}, "first subdomain label"},
+		{"second wildcard", Config{AllowedOrigins: []string{"https://*.*.example.com"}}, "invalid wildcard"},
+		{"bare wildcard", Config{AllowedOrigins: []string{"https://*."}}, "invalid wildcard"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+	if _, err := New(Config{AllowedOrigins: []string{"*"}}); err != nil {
+		t.Errorf("origin * without credentials: %v", err)
+	}
+}
+
+func TestOrigins(t *testing.T) {
+	r := newEngine(t, Config{
+		AllowedOrigins: []string{"https://pokedex.example.com/", "https://*.example.org", "http://*.localhost:5173"},
+		ExposedHeaders: []string{"ETag", "X-Request-ID"},
+	})
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://pokedex.example.com", true},
+		{"HTTPS://Pokedex.Example.com", true},
+		{"https://other.example.com", false},
+		{"https://app.example.org", true},
+		{"https://a.b.example.org", true},
+		{"https://example.org", false},
+		{"http://app.example.org", false},
+		{"https://evilexample.org", false},
+		{"https://app.example.org.evil.com", false},
+		{"https://user@app.example.org", false},
+		{"http://app.localhost:5173", true},
+		{"http://app.localhost:3000", false},
+		{"http://evil.com:1/.localhost:5173", false},
+	}
+	for _, tt := range tests {
+		w := request(r, http.MethodGet, tt.origin, nil)
+		got := w.Header().Get("Access-Control-Allow-Origin")
+		if tt.allowed && (got != tt.origin || w.Header().Get("Access-Control-Expose-Headers") != "ETag, X-Request-ID") {
+			t.Errorf("%s: Allow-Origin %q, Expose-Headers %q, want it allowed", tt.origin, got, w.Header().Get("Access-Control-Expose-Headers"))
+		}
+		if !tt.allowed && got != "" {
+			t.Errorf("%s: Allow-Origin %q, want it rejected", tt.origin, got)
+		}
+		if w.Code != http.StatusOK || w.Header().Get("Vary") != "Origin" {
+			t.Errorf("%s: status %d, Vary %q, want the request served with Vary: Origin", tt.origin, w.Code, w.Header().Get("Vary"))
+		}
+	}
+}
+
+func TestAnyOrigin(t *testing.T) {
+	r := newEngine(t, Config{AllowedOrigins: []string{"*"}})
+	w := request(r, http.MethodGet, "https://anywhere.test", nil)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Allow-Origin = %q, want *", got)
+	}
+	if w.Header().Get("Vary") != "" || w.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Errorf("Vary %q, Allow-Credentials %q, want neither for *", w.Header().Get("Vary"), w.Header().Get("Access-Control-Allow-Credentials"))
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	r := newEngine(t, Config{
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowedMethods:   []string{"get", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+	const origin = "https://app.example.com"
+	tests := []struct {
+		name    string
+		origin  string
+		method  string
+		headers string
+		allowed bool
+	}{
+		{"allowed", origin, "POST", "content-type, x-request-id", true},
+		{"lower-case method", origin, "get", "", true},
+		{"method not allowed", origin, "DELETE", "", false},
+		{"header not allowed", origin, "POST", "Content-Type, Authorization", false},
+		{"origin not allowed", "https://example.net", "POST", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := map[string]string{"Access-Control-Request-Method": tt.method}
+			if tt.headers != "" {
+				header["Access-Control-Request-Headers"] = tt.headers
+			}
+			w := request(r, http.MethodOptions, tt.origin, header)
+			if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+				t.Fatalf("status %d, body %q, want an empty 204 that never reaches the routes", w.Code, w.Body)
+			}
+			h := w.Header()
+			if !tt.allowed {
+				for _, name := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials", "Access-Control-Allow-Methods"} {
+					if h.Get(name) != "" {
+						t.Errorf("%s = %q, want it absent so the browser fails the preflight", name, h.Get(name))
+					}
+				}
+				return
+			}
+			want := map[string]string{
+				"Access-Control-Allow-Origin":      origin,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, POST",
+				"Access-Control-Allow-Headers":     "Content-Type, X-Request-ID",
+				"Access-Control-Max-Age":           "600",
+			}
+			for name, v := range want {
+				if h.Get(name) != v {
+					t.Errorf("%s = %q, want %q", name, h.Get(name), v)
+				}
+			}
+			if vary := strings.Join(h.Values("Vary"), ", "); vary != "Origin, Access-Control-Request-Method, Access-Control-Request-Headers" {
+				t.Errorf("Vary = %q", vary)
+			}
+		})
+	}
+
+	// An OPTIONS request without Access-Control-Request-Method is not a
+	// preflight and reaches the router.
+	if w := request(r, http.MethodOptions, origin, nil); w.Code == http.StatusNoContent {
+		t.Error("plain OPTIONS was answered as a preflight")
+	}
+}
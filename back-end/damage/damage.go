@@ -0,0 +1,108 @@
+// Package damage implements the damage formula of the main series games from
+// generation 5 on: base damage from the attacker's level, the move's power
+// and the attacking and defending stats, then the critical hit, random roll,
+// STAB and type effectiveness modifiers, each rounded down in that order as
+// the games do.
+package damage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The random roll multiplies damage by MinRoll% to MaxRoll%, one of 16 values.
+const (
+	MinRoll = 85
+	MaxRoll = 100
+)
+
+// Modifiers of a critical hit and of a move matching one of the attacker's
+// types (same-type attack bonus).
+const (
+	CriticalMultiplier = 1.5
+	STABMultiplier     = 1.5
+)
+
+var ErrInvalidInput = errors.New("invalid damage input")
+
+// Input is everything the formula depends on. Attack and Defense are actual
+// stats, not base stats: Attack or Sp. Atk of the attacker and Defense or
+// Sp. Def of the defender, matching the move's category.
+type Input struct {
+	Level         int     // attacker's level, 1-100
+	Power         int     // the move's base power
+	Attack        int     // attacker's attacking stat
+	Defense       int     // defender's defending stat
+	STAB          bool    // the move's type is one of the attacker's types
+	Effectiveness float64 // product of the multipliers against each defending type
+	Critical      bool
+}
+
+// Result is the damage of every random roll.
+type Result struct {
+	Rolls []int `json:"rolls" doc:"Damage for each random roll from 85% to 100%"`
+	Min   int   `json:"min"`
+	Max   int   `json:"max"`
+}
+
+// Calculate applies the formula to in.
+func Calculate(in Input) (Result, error) {
+	if err := in.validate(); err != nil {
+		return Result{}, err
+	}
+
+	base := Base(in.Level, in.Power, in.Attack, in.Defense)
+	if in.Critical {
+		base = floor(base, CriticalMultiplier)
+	}
+
+	r := Result{Rolls: make([]int, 0, MaxRoll-MinRoll+1)}
+	for roll := MinRoll; roll <= MaxRoll; roll++ {
+		d := base * roll / 100
+		if in.STAB {
+			d = floor(d, STABMultiplier)
+		}
+		d = floor(d, in.Effectiveness)
+		if d < 1 && in.Effectiveness > 0 {
+			// A move that can hit always deals at least 1 HP.
+			d = 1
+		}
+		r.Rolls = append(r.Rolls, d)
+	}
+	r.Min, r.Max = r.Rolls[0], r.Rolls[len(r.Rolls)-1]
+	return r, nil
+}
+
+// Base is the damage before any modifier:
+// floor(floor(floor(2*level/5+2) * power * attack / defense) / 50) + 2.
+func Base(level, power, attack, defense int) int {
+	return (2*level/5+2)*power*attack/defense/50 + 2
+}
+
+// Percent is damage as a percentage of hp, rounded to one decimal.
+func Percent(damage, hp int) float64 {
+	if hp <= 0 {
+		return 0
+	}
+	return math.Round(float64(damage)*1000/float64(hp)) / 10
+}
+
+func (in Input) validate() error {
+	switch {
+	case in.Level < 1 || in.Level > 100:
+		return fmt.Errorf("level %d: %w", in.Level, ErrInvalidInput)
+	case in.Power < 1:
+		return fmt.Errorf("power %d: %w", in.Power, ErrInvalidInput)
+	case in.Attack < 1 || in.Defense < 1:
+		return fmt.Errorf("stats %d/%d: %w", in.Attack, in.Defense, ErrInvalidInput)
+	case in.Effectiveness < 0:
+		return fmt.Errorf("effectiveness %g: %w", in.Effectiveness, ErrInvalidInput)
+	}
+	return nil
+}
+
+// floor multiplies d by m and rounds down.
+func floor(d int, m float64) int {
+	return int(math.Floor(float64(d) * m))
+}
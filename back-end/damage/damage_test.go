@@ -0,0 +1,109 @@
+package damage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       Input
+		min, max int
+	}{
+		{
+			// The worked example of the formula on Bulbapedia: a level 75
+			// Glaceon's Ice Fang against Garchomp.
+			name: "STAB 4x",
+			in:   Input{Level: 75, Power: 65, Attack: 123, Defense: 163, STAB: true, Effectiveness: 4},
+			min:  168, max: 196,
+		},
+		{
+			name: "neutral",
+			in:   Input{Level: 50, Power: 90, Attack: 100, Defense: 100, Effectiveness: 1},
+			min:  34, max: 41,
+		},
+		{
+			name: "critical",
+			in:   Input{Level: 50, Power: 90, Attack: 100, Defense: 100, Effectiveness: 1, Critical: true},
+			min:  51, max: 61,
+		},
+		{
+			name: "STAB resisted",
+			in:   Input{Level: 100, Power: 120, Attack: 200, Defense: 150, STAB: true, Effectiveness: 0.5},
+			min:  86, max: 102,
+		},
+		{
+			name: "at least 1",
+			in:   Input{Level: 1, Power: 10, Attack: 5, Defense: 200, Effectiveness: 0.25},
+			min:  1, max: 1,
+		},
+		{
+			name: "immune",
+			in:   Input{Level: 100, Power: 150, Attack: 300, Defense: 50, STAB: true, Effectiveness: 0},
+			min:  0, max: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Min != tt.min || got.Max != tt.max {
+				t.Errorf("damage = %d-%d, want %d-%d", got.Min, got.Max, tt.min, tt.max)
+			}
+			if len(got.Rolls) != MaxRoll-MinRoll+1 {
+				t.Errorf("%d rolls, want %d", len(got.Rolls), MaxRoll-MinRoll+1)
+			}
+			for i := 1; i < len(got.Rolls); i++ {
+				if got.Rolls[i] < got.Rolls[i-1] {
+					t.Errorf("rolls not ascending: %v", got.Rolls)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateInvalid(t *testing.T) {
+	valid := Input{Level: 50, Power: 90, Attack: 100, Defense: 100, Effectiveness: 1}
+	tests := []struct {
+		name   string
+		change func(*Input)
+	}{
+		{"level 0", func(in *Input) { in.Level = 0 }},
+		{"level 101", func(in *Input) { in.Level = 101 }},
+		{"no power", func(in *Input) { in.Power = 0 }},
+		{"no attack", func(in *Input) { in.Attack = 0 }},
+		{"no defense", func(in *Input) { in.Defense = 0 }},
+		{"negative effectiveness", func(in *Input) { in.Effectiveness = -1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := valid
+			tt.change(&in)
+			if _, err := Calculate(in); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("err = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		damage, hp int
+		want       float64
+	}{
+		{50, 200, 25},
+		{1, 3, 33.3},
+		{2, 3, 66.7},
+		{300, 150, 200},
+		{10, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := Percent(tt.damage, tt.hp); got != tt.want {
+			t.Errorf("Percent(%d, %d) = %g, want %g", tt.damage, tt.hp, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Command docsIntegrity pins the CDN assets of the documentation pages with
+// Subresource Integrity: it downloads every external script and stylesheet
+// of api/swagger.html and api/graphiql.html and writes its sha384 digest
+// into the tag's integrity attribute, so browsers refuse a file that differs
+// from the one pinned. Rerun it after changing an asset's version.
+package main
+
+import (
+	"GO-Mongo/config"
+	"GO-Mongo/logger"
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+)
+
+// pages คือหน้าเอกสารที่โหลด asset จาก CDN
+var pages = []string{"api/swagger.html", "api/graphiql.html"}
+
+func main() {
+	cfg, err := config.LoadConfig("env.yaml") // โหลดการตั้งค่า logging จากไฟล์ env.yaml
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+	appLogger, err := logger.New(cfg.Logging)
+	if err != nil {
+		log.Fatal("Failed to create logger:", err)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	for _, path := range pages {
+		page, err := os.ReadFile(path)
+		if err != nil {
+			fatal(appLogger, "failed to read page", err)
+		}
+		pinned, n, err := addIntegrity(page, func(url string) ([]byte, error) { return download(client, url) })
+		if err != nil {
+			fatal(appLogger, "failed to pin "+path, err)
+		}
+		if err := os.WriteFile(path, pinned, 0o644); err != nil {
+			fatal(appLogger, "failed to write page", err)
+		}
+		appLogger.Info("pinned assets", "page", path, "assets", n)
+	}
+}
+
+func fatal(l *slog.Logger, msg string, err error) {
+	l.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+func download(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+var (
+	// assetTag matches the opening tag of a script or stylesheet.
+	assetTag = regexp.MustCompile(`<(?:script|link)\b[^>]*>`)
+	// assetURL is the absolute URL a tag loads.
+	assetURL = regexp.MustCompile(`\s(?:src|href)="(https://[^"]+)"`)
+	// sriAttrs are the attributes addIntegrity replaces.
+	sriAttrs = regexp.MustCompile(`\s(?:integrity="[^"]*"|crossorigin(?:="[^"]*")?)`)
+)
+
+// addIntegrity sets integrity and crossorigin on every tag of page that loads
+// an absolute URL, fetching each asset with fetch. It returns the new page
+// and the number of tags pinned.
+func addIntegrity(page []byte, fetch func(url string) ([]byte, error)) ([]byte, int, error) {
+	var err error
+	n := 0
+	out := assetTag.ReplaceAllFunc(page, func(tag []byte) []byte {
+		m := assetURL.FindSubmatch(tag)
+		if m == nil || err != nil {
+			return tag
+		}
+		var body []byte
+		if body, err = fetch(string(m[1])); err != nil {
+			return tag
+		}
+		sum := sha512.Sum384(body)
+		tag = sriAttrs.ReplaceAll(tag, nil)
+		head, closing := bytes.TrimSuffix(tag, []byte(">")), ">"
+		if bytes.HasSuffix(head, []byte("/")) {
+			head, closing = head[:len(head)-1], " />"
+		}
+		n++
+		return []byte(string(bytes.TrimRight(head, " ")) +
+			` integrity="sha384-` + base64.StdEncoding.EncodeToString(sum[:]) + `" crossorigin="anonymous"` + closing)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, n, nil
+}
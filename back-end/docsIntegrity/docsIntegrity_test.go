@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func sri(body string) string {
+	sum := sha512.Sum384([]byte(body))
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestAddIntegrity(t *testing.T) {
+	assets := map[string]string{
+		"https://cdn.test/app.css": "body {}",
+		"https://cdn.test/app.js":  "run()",
+	}
+	fetch := func(url string) ([]byte, error) {
+		body, ok := assets[url]
+		if !ok {
+			return nil, errors.New("unexpected fetch of " + url)
+		}
+		return []byte(body), nil
+	}
+	page := `<link rel="stylesheet" href="https://cdn.test/app.css">
+<link rel="icon" href="favicon.ico" />
+<script src="https://cdn.test/app.js" crossorigin></script>
+<script>run()</script>
+`
+	got, n, err := addIntegrity([]byte(page), fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<link rel="stylesheet" href="https://cdn.test/app.css" integrity="` + sri("body {}") + `" crossorigin="anonymous">
+<link rel="icon" href="favicon.ico" />
+<script src="https://cdn.test/app.js" integrity="` + sri("run()") + `" crossorigin="anonymous"></script>
+<script>run()</script>
+`
+	if string(got) != want || n != 2 {
+		t.Fatalf("pinned %d assets:\n%s\nwant 2:\n%s", n, got, want)
+	}
+
+	// A changed asset replaces the old digest instead of adding another.
+	assets["https://cdn.test/app.js"] = "run(2)"
+	again, _, err := addIntegrity(got, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(again), "integrity=") != 2 || !strings.Contains(string(again), sri("run(2)")) {
+		t.Errorf("repinned page:\n%s", again)
+	}
+
+	delete(assets, "https://cdn.test/app.css")
+	if _, _, err := addIntegrity([]byte(page), fetch); err == nil {
+		t.Error("a failed download was ignored")
+	}
+}
+
+// Every absolute asset of the pages is matched, so none is left unpinned.
+func TestPagesAssetsMatched(t *testing.T) {
+	for _, page := range pages {
+		data, err := os.ReadFile(filepath.Join("..", page))
+		if err != nil {
+			t.Fatal(err)
+		}
+		external := len(regexp.MustCompile(`(?:src|href)="https://`).FindAll(data, -1))
+		var fetched int
+		if _, _, err := addIntegrity(data, func(string) ([]byte, error) { fetched++; return nil, nil }); err != nil {
+			t.Fatal(err)
+		}
+		if external == 0 || fetched != external {
+			t.Errorf("%s: %d external assets, %d pinned", page, external, fetched)
+		}
+	}
+}
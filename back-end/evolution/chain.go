@@ -0,0 +1,76 @@
+package evolution
+
+import (
+	"GO-Mongo/models"
+	"strings"
+)
+
+// Stage is one Pokemon in an evolution chain and the stages it evolves into.
+type Stage struct {
+	Name      string `json:"name"`
+	DexNumber string `json:"dex_number,omitempty" doc:"Empty for Pokemon outside the served datasets"`
+	// Method is how the previous stage evolves into this one; nil on the
+	// first stage.
+	Method    *models.EvolutionMethod `json:"method,omitempty"`
+	EvolvesTo []Stage                 `json:"evolves_to"`
+}
+
+// Chain returns the whole family tree containing name, starting from its
+// first stage, including every branch (e.g. all of Eevee's evolutions).
+// A Pokemon without evolutions is a chain of one stage.
+func Chain(evolutions []models.Evolution, name string) Stage {
+	children := map[string][]models.Evolution{}
+	parent := map[string]string{}
+	display := map[string]string{key(name): strings.TrimSpace(name)}
+	for _, e := range evolutions {
+		from, to := key(e.From), key(e.To)
+		children[from] = append(children[from], e)
+		parent[to] = from
+		display[from], display[to] = strings.TrimSpace(e.From), strings.TrimSpace(e.To)
+	}
+
+	root := key(name)
+	seen := map[string]bool{root: true}
+	for {
+		p, ok := parent[root]
+		if !ok || seen[p] {
+			break
+		}
+		seen[p] = true
+		root = p
+	}
+
+	var build func(k string, method *models.EvolutionMethod, visited map[string]bool) Stage
+	build = func(k string, method *models.EvolutionMethod, visited map[string]bool) Stage {
+		visited[k] = true
+		s := Stage{Name: display[k], Method: method, EvolvesTo: []Stage{}}
+		for _, e := range children[k] {
+			if to := key(e.To); !visited[to] {
+				s.EvolvesTo = append(s.EvolvesTo, build(to, &e.EvolutionMethod, visited))
+			}
+		}
+		return s
+	}
+	return build(root, nil, map[string]bool{})
+}
+
+// Names lists every stage of the chain, first stage first.
+func (s Stage) Names() []string {
+	names := []string{s.Name}
+	for _, next := range s.EvolvesTo {
+		names = append(names, next.Names()...)
+	}
+	return names
+}
+
+// SetDexNumbers fills DexNumber from dex, keyed by lower-case name.
+func (s *Stage) SetDexNumbers(dex map[string]string) {
+	s.DexNumber = dex[key(s.Name)]
+	for i := range s.EvolvesTo {
+		s.EvolvesTo[i].SetDexNumbers(dex)
+	}
+}
+
+func key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
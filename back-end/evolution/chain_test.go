@@ -0,0 +1,61 @@
+package evolution
+
+import (
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/models"
+	"slices"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	evolutions := fixture.Evolutions(t)
+	tests := []struct {
+		name string
+		want []string // Names, first stage first
+	}{
+		{"Bulbasaur", []string{"Bulbasaur", "Ivysaur", "Venusaur"}},
+		// Any stage returns the whole family, from the first stage.
+		{"  venusaur ", []string{"Bulbasaur", "Ivysaur", "Venusaur"}},
+		{"Pikachu", []string{"Pichu", "Pikachu", "Raichu"}},
+		{"Flareon", []string{"Eevee", "Vaporeon", "Jolteon", "Flareon", "Espeon", "Umbreon"}},
+		{"Hitmontop", []string{"Tyrogue", "Hitmonlee", "Hitmonchan", "Hitmontop"}},
+		{"Oddish", []string{"Oddish", "Gloom", "Vileplume", "Bellossom"}},
+		{"Tauros", []string{"Tauros"}},
+	}
+	for _, tt := range tests {
+		if got := Chain(evolutions, tt.name).Names(); !slices.Equal(got, tt.want) {
+			t.Errorf("Chain(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	eevee := Chain(evolutions, "Umbreon")
+	if eevee.Method != nil || len(eevee.EvolvesTo) != 5 {
+		t.Fatalf("Eevee = %+v, want a first stage without method and five branches", eevee)
+	}
+	if m := eevee.EvolvesTo[0].Method; m == nil || m.Trigger != models.TriggerItem || m.Item != "Water Stone" {
+		t.Errorf("Vaporeon's method = %+v, want the Water Stone", m)
+	}
+	if single := Chain(evolutions, "Tauros"); single.EvolvesTo == nil || single.Method != nil {
+		t.Errorf("Tauros = %+v, want an empty, non-nil EvolvesTo", single)
+	}
+}
+
+func TestChainWithCycle(t *testing.T) {
+	// A bad document must not loop forever.
+	evolutions := []models.Evolution{
+		{From: "A", To: "B", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerLevel, Level: 10}},
+		{From: "B", To: "A", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerLevel, Level: 20}},
+	}
+	if got := Chain(evolutions, "b").Names(); !slices.Equal(got, []string{"A", "B"}) {
+		t.Errorf("got %v, want [A B]", got)
+	}
+}
+
+func TestSetDexNumbers(t *testing.T) {
+	chain := Chain(fixture.Evolutions(t), "Charmander")
+	chain.SetDexNumbers(map[string]string{"charmander": "#0004", "charizard": "#0006"})
+	got := []string{chain.DexNumber, chain.EvolvesTo[0].DexNumber, chain.EvolvesTo[0].EvolvesTo[0].DexNumber}
+	if !slices.Equal(got, []string{"#0004", "", "#0006"}) {
+		t.Errorf("dex numbers = %q", got)
+	}
+}
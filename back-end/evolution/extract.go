@@ -0,0 +1,267 @@
+// Package evolution turns evolution data into chains and seeds that data
+// from the free-text bios of the datasets.
+package evolution
+
+import (
+	"GO-Mongo/models"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Extraction is the result of Extract.
+type Extraction struct {
+	Evolutions []models.Evolution
+	Report     Report
+}
+
+// Extract reads the "It evolves into/from ..." sentences of every bio. Each
+// evolution is usually described by both Pokemon, so the two descriptions
+// are merged; everything the parser could not settle is listed in the
+// report for a human to review before the result is imported.
+func Extract(pokemons []models.Pokemon) Extraction {
+	known := make(map[string]bool, len(pokemons))
+	for _, p := range pokemons {
+		known[strings.ToLower(strings.TrimSpace(p.Name))] = true
+	}
+
+	x := &extractor{byPair: map[[2]string]int{}}
+	for _, p := range pokemons {
+		name := strings.TrimSpace(p.Name)
+		for _, sentence := range splitSentences(p.Bio) {
+			switch {
+			case strings.HasPrefix(sentence, "It evolves "):
+				x.sentence(name, sentence)
+			case strings.Contains(sentence, "it evolves "):
+				// e.g. "In Pokémon Legends: Arceus, it evolves into Wyrdeer ..."
+				x.report.Unparsed = append(x.report.Unparsed, Unparsed{Pokemon: name, Sentence: sentence})
+			}
+		}
+	}
+
+	for _, e := range reviewed {
+		if known[strings.ToLower(e.From)] {
+			x.add("review", e)
+		}
+	}
+
+	for _, e := range x.evolutions {
+		if e.Trigger == "" {
+			e.Trigger = models.TriggerOther
+			x.report.MissingTrigger = append(x.report.MissingTrigger, e)
+		} else if e.Trigger == models.TriggerOther {
+			x.report.OtherTrigger = append(x.report.OtherTrigger, e)
+		}
+		x.result = append(x.result, e)
+		for _, name := range []string{e.From, e.To} {
+			if !known[strings.ToLower(name)] && !slices.Contains(x.report.OutsideDatasets, name) {
+				x.report.OutsideDatasets = append(x.report.OutsideDatasets, name)
+			}
+		}
+	}
+	x.report.Evolutions = len(x.result)
+	x.report.Pokemon = len(pokemons)
+	return Extraction{Evolutions: x.result, Report: x.report}
+}
+
+type extractor struct {
+	evolutions []models.Evolution
+	byPair     map[[2]string]int // lower-case from/to to index in evolutions
+	result     []models.Evolution
+	report     Report
+}
+
+// connector splits an evolution sentence into "<name> <method>" segments.
+var connector = regexp.MustCompile(`(?:It evolves (into|from)(?: either)?|,? which evolves (into)(?: either)?|,? and evolves (into)(?: either)?| (or))\s+`)
+
+// regionalForm matches names of regional variants, which the bios describe
+// too loosely to attach to the right base form.
+var regionalForm = regexp.MustCompile(`^(?:Alolan|Galarian|Hisuian|Paldean) `)
+
+// regionalOnly are Pokemon that only a regional variant evolves into, though
+// the bios name them without the variant, e.g. "It evolves into Perrserker"
+// on Meowth's.
+var regionalOnly = map[string]bool{
+	"perrserker": true, // Galarian Meowth
+	"sirfetch'd": true, // Galarian Farfetch'd
+	"mr. rime":   true, // Galarian Mr. Mime
+	"cursola":    true, // Galarian Corsola
+	"overqwil":   true, // Hisuian Qwilfish
+	"sneasler":   true, // Hisuian Sneasel
+	"clodsire":   true, // Paldean Wooper
+}
+
+// reviewed are evolutions the bios state only in sentences the grammar
+// rightly skips, e.g. "In Pokémon Legends: Arceus, it evolves into Wyrdeer
+// after ...", added once a human checked them.
+var reviewed = []models.Evolution{
+	{From: "Stantler", To: "Wyrdeer", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerOther, Condition: "after using Psyshield Bash in the agile style at least 20 times"}},
+}
+
+func isRegional(name string) bool {
+	return regionalForm.MatchString(name) || regionalOnly[strings.ToLower(name)]
+}
+
+func (x *extractor) sentence(subject, sentence string) {
+	matches := connector.FindAllStringSubmatchIndex(sentence, -1)
+	if len(matches) == 0 || matches[0][0] != 0 {
+		x.report.Unparsed = append(x.report.Unparsed, Unparsed{Pokemon: subject, Sentence: sentence})
+		return
+	}
+
+	type segment struct {
+		from, name, method string
+	}
+	var segments []segment
+	previous := subject // the stage a "which evolves into" continues from
+	for i, m := range matches {
+		if i > 0 && m[2] >= 0 {
+			// A second "It evolves" means the sentence split went wrong.
+			x.report.Unparsed = append(x.report.Unparsed, Unparsed{Pokemon: subject, Sentence: sentence})
+			return
+		}
+		end := len(sentence)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		text := strings.TrimRight(strings.TrimSpace(sentence[m[1]:end]), ".,")
+		name, method := splitName(text)
+
+		switch {
+		case m[2] >= 0 && sentence[m[2]:m[3]] == "from":
+			segments = append(segments, segment{from: name, name: subject, method: method})
+			previous = subject
+		case m[4] >= 0: // which evolves into
+			segments = append(segments, segment{from: previous, name: name, method: method})
+			previous = name
+		case m[8] >= 0: // or
+			if name == "" && len(segments) > 0 {
+				// "when traded or when exposed to a Linking Cord"
+				segments[len(segments)-1].method += " or " + text
+				continue
+			}
+			from := subject
+			if len(segments) > 0 {
+				from = segments[len(segments)-1].from
+			}
+			segments = append(segments, segment{from: from, name: name, method: method})
+		default: // It evolves into, and evolves into
+			segments = append(segments, segment{from: subject, name: name, method: method})
+			previous = name
+		}
+	}
+
+	for _, s := range segments {
+		if s.from == "" || s.name == "" {
+			x.report.Unparsed = append(x.report.Unparsed, Unparsed{Pokemon: subject, Sentence: sentence})
+			return
+		}
+	}
+	regional := false
+	for _, s := range segments {
+		if isRegional(s.from) || isRegional(s.name) {
+			regional = true
+			continue
+		}
+		x.add(subject, models.Evolution{From: s.from, To: s.name, EvolutionMethod: parseMethod(s.method)})
+	}
+	if regional {
+		x.report.RegionalForms = append(x.report.RegionalForms, Unparsed{Pokemon: subject, Sentence: sentence})
+	}
+}
+
+// add records e, merging it with an earlier description of the same pair.
+func (x *extractor) add(source string, e models.Evolution) {
+	key := [2]string{strings.ToLower(e.From), strings.ToLower(e.To)}
+	i, ok := x.byPair[key]
+	if !ok {
+		x.byPair[key] = len(x.evolutions)
+		x.evolutions = append(x.evolutions, e)
+		return
+	}
+	prev := &x.evolutions[i]
+	switch {
+	case prev.Trigger == "":
+		prev.EvolutionMethod = e.EvolutionMethod
+	case e.Trigger == "" || prev.EvolutionMethod == e.EvolutionMethod:
+		// Nothing new.
+	default:
+		x.report.Conflicts = append(x.report.Conflicts, Conflict{Kept: *prev, Dropped: e, Source: source})
+	}
+}
+
+var (
+	levelMethod      = regexp.MustCompile(`^(?:starting )?at level (\d+)\s*(.*)$`)
+	itemMethod       = regexp.MustCompile(`^when exposed to (?:a|an|the) (.+?)(?:\s+(during .+|while .+))?$`)
+	tradeMethod      = regexp.MustCompile(`^when traded(?: while holding (?:a|an) (.+?))?(?:\s+(or .+))?$`)
+	friendshipMethod = regexp.MustCompile(`^when leveled up with high friendship\s*(.*)$`)
+	levelUpMethod    = regexp.MustCompile(`^when leveled up\s+(.+)$`)
+)
+
+// parseMethod classifies the text following a name, e.g. "starting at level
+// 16" or "when traded while holding a Metal Coat". Text it does not
+// recognize becomes the condition of TriggerOther; no text at all leaves the
+// trigger empty.
+func parseMethod(text string) models.EvolutionMethod {
+	text = strings.TrimSpace(text)
+	if m := levelMethod.FindStringSubmatch(text); m != nil {
+		level, _ := strconv.Atoi(m[1])
+		return models.EvolutionMethod{Trigger: models.TriggerLevel, Level: level, Condition: strings.TrimLeft(m[2], ", ")}
+	}
+	if m := itemMethod.FindStringSubmatch(text); m != nil {
+		return models.EvolutionMethod{Trigger: models.TriggerItem, Item: m[1], Condition: m[2]}
+	}
+	if m := tradeMethod.FindStringSubmatch(text); m != nil {
+		return models.EvolutionMethod{Trigger: models.TriggerTrade, Item: m[1], Condition: m[2]}
+	}
+	if m := friendshipMethod.FindStringSubmatch(text); m != nil {
+		return models.EvolutionMethod{Trigger: models.TriggerFriendship, Condition: m[1]}
+	}
+	if m := levelUpMethod.FindStringSubmatch(text); m != nil {
+		return models.EvolutionMethod{Trigger: models.TriggerLevel, Condition: m[1]}
+	}
+	if text == "" {
+		return models.EvolutionMethod{}
+	}
+	return models.EvolutionMethod{Trigger: models.TriggerOther, Condition: text}
+}
+
+// splitName separates the leading Pokemon name, a run of capitalized words
+// such as "Mr. Mime" or "Nidoran♀", from the method text after it.
+func splitName(text string) (name, rest string) {
+	words := strings.Fields(text)
+	n := 0
+	for n < len(words) {
+		r, _ := utf8.DecodeRuneInString(words[n])
+		if !unicode.IsUpper(r) {
+			break
+		}
+		n++
+	}
+	return strings.Join(words[:n], " "), strings.Join(words[n:], " ")
+}
+
+// splitSentences splits text after every period followed by a space,
+// except in the abbreviations "Mr." and "Jr.".
+func splitSentences(text string) []string {
+	var out []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '.' || i+1 < len(text) && text[i+1] != ' ' {
+			continue
+		}
+		word := text[strings.LastIndexByte(text[:i], ' ')+1 : i]
+		if word == "Mr" || word == "Jr" {
+			continue
+		}
+		out = append(out, strings.TrimSpace(text[start:i+1]))
+		start = i + 1
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		out = append(out, rest)
+	}
+	return out
+}
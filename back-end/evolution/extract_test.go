@@ -0,0 +1,130 @@
+package evolution
+
+import (
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/models"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseMethod(t *testing.T) {
+	tests := []struct {
+		text string
+		want models.EvolutionMethod
+	}{
+		{"starting at level 16", models.EvolutionMethod{Trigger: models.TriggerLevel, Level: 16}},
+		{"at level 20, when Tyrogue's Attack is higher than its Defense", models.EvolutionMethod{Trigger: models.TriggerLevel, Level: 20, Condition: "when Tyrogue's Attack is higher than its Defense"}},
+		{"when exposed to a Fire Stone", models.EvolutionMethod{Trigger: models.TriggerItem, Item: "Fire Stone"}},
+		{"when exposed to a Peat Block during a full moon", models.EvolutionMethod{Trigger: models.TriggerItem, Item: "Peat Block", Condition: "during a full moon"}},
+		{"when traded", models.EvolutionMethod{Trigger: models.TriggerTrade}},
+		{"when traded while holding a King's Rock", models.EvolutionMethod{Trigger: models.TriggerTrade, Item: "King's Rock"}},
+		{"when leveled up with high friendship during the day", models.EvolutionMethod{Trigger: models.TriggerFriendship, Condition: "during the day"}},
+		{"when leveled up while knowing Rollout", models.EvolutionMethod{Trigger: models.TriggerLevel, Condition: "while knowing Rollout"}},
+		{"after using Rage Fist 20 times", models.EvolutionMethod{Trigger: models.TriggerOther, Condition: "after using Rage Fist 20 times"}},
+		{"", models.EvolutionMethod{}},
+	}
+	for _, tt := range tests {
+		if got := parseMethod(tt.text); got != tt.want {
+			t.Errorf("parseMethod(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	got := splitSentences("It evolves from Mime Jr. when leveled up. Mr. Mime mimes.Really. ")
+	want := []string{"It evolves from Mime Jr. when leveled up.", "Mr. Mime mimes.Really."}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	pokemons := []models.Pokemon{
+		{Name: "Bulbasaur", Bio: "A seed Pokémon. It evolves into Ivysaur starting at level 16, which evolves into Venusaur starting at level 32."},
+		{Name: "Ivysaur", Bio: "It evolves from Bulbasaur starting at level 16 and evolves into Venusaur starting at level 32."},
+		{Name: "Venusaur", Bio: "It evolves from Ivysaur starting at level 32."},
+		// Both branches come from the subject.
+		{Name: "Poliwhirl", Bio: "It evolves into Poliwrath when exposed to a Water Stone or Politoed when traded while holding a King's Rock."},
+		// The other method follows "or" without a name.
+		{Name: "Graveler", Bio: "It evolves into Golem when traded or when exposed to a Linking Cord."},
+		// Marowak's bio disagrees with Cubone's; the first is kept.
+		{Name: "Cubone", Bio: "It evolves into Marowak starting at level 28."},
+		{Name: "Marowak", Bio: "It evolves from Cubone when leveled up at night starting at level 28."},
+		{Name: "Meowth", Bio: "It evolves into Persian starting at level 28. It evolves into Alolan Persian when leveled up with high friendship. It evolves into Perrserker starting at level 28."},
+		{Name: "Eevee", Bio: "It evolves into one of eight different Pokémon through various methods."},
+		{Name: "Onix", Bio: "It evolves into Steelix."},
+		{Name: "Stantler", Bio: "In Pokémon Legends: Arceus, it evolves into Wyrdeer after using Psyshield Bash in the agile style at least 20 times."},
+	}
+	got := Extract(pokemons)
+
+	level := func(n int) models.EvolutionMethod {
+		return models.EvolutionMethod{Trigger: models.TriggerLevel, Level: n}
+	}
+	want := []models.Evolution{
+		{From: "Bulbasaur", To: "Ivysaur", EvolutionMethod: level(16)},
+		{From: "Ivysaur", To: "Venusaur", EvolutionMethod: level(32)},
+		{From: "Poliwhirl", To: "Poliwrath", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerItem, Item: "Water Stone"}},
+		{From: "Poliwhirl", To: "Politoed", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerTrade, Item: "King's Rock"}},
+		{From: "Graveler", To: "Golem", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerTrade, Condition: "or when exposed to a Linking Cord"}},
+		{From: "Cubone", To: "Marowak", EvolutionMethod: level(28)},
+		{From: "Meowth", To: "Persian", EvolutionMethod: level(28)},
+		{From: "Onix", To: "Steelix", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerOther}},
+		{From: "Stantler", To: "Wyrdeer", EvolutionMethod: models.EvolutionMethod{Trigger: models.TriggerOther, Condition: "after using Psyshield Bash in the agile style at least 20 times"}},
+	}
+	if !reflect.DeepEqual(got.Evolutions, want) {
+		t.Errorf("evolutions =\n%+v\nwant\n%+v", got.Evolutions, want)
+	}
+
+	r := got.Report
+	if r.Pokemon != len(pokemons) || r.Evolutions != len(want) {
+		t.Errorf("report counts %d bios, %d evolutions", r.Pokemon, r.Evolutions)
+	}
+	if len(r.Conflicts) != 1 || r.Conflicts[0].Source != "Marowak" || r.Conflicts[0].Dropped.Condition != "at night starting at level 28" {
+		t.Errorf("conflicts = %+v, want Marowak's description", r.Conflicts)
+	}
+	wantUnparsed := []Unparsed{
+		{Pokemon: "Eevee", Sentence: "It evolves into one of eight different Pokémon through various methods."},
+		{Pokemon: "Stantler", Sentence: "In Pokémon Legends: Arceus, it evolves into Wyrdeer after using Psyshield Bash in the agile style at least 20 times."},
+	}
+	if !reflect.DeepEqual(r.Unparsed, wantUnparsed) {
+		t.Errorf("unparsed = %+v", r.Unparsed)
+	}
+	var regional []string
+	for _, u := range r.RegionalForms {
+		regional = append(regional, u.Sentence)
+	}
+	if !slices.Equal(regional, []string{
+		"It evolves into Alolan Persian when leveled up with high friendship.",
+		"It evolves into Perrserker starting at level 28.",
+	}) {
+		t.Errorf("regional forms = %q", regional)
+	}
+	if len(r.MissingTrigger) != 1 || r.MissingTrigger[0].To != "Steelix" || len(r.OtherTrigger) != 1 || r.OtherTrigger[0].To != "Wyrdeer" {
+		t.Errorf("missing trigger %+v, other trigger %+v", r.MissingTrigger, r.OtherTrigger)
+	}
+	if !slices.Equal(r.OutsideDatasets, []string{"Poliwrath", "Politoed", "Golem", "Persian", "Steelix", "Wyrdeer"}) {
+		t.Errorf("outside datasets = %v", r.OutsideDatasets)
+	}
+
+	// Reviewed evolutions only apply to Pokemon that are in the datasets.
+	if got := Extract(pokemons[:3]); len(got.Evolutions) != 2 {
+		t.Errorf("Bulbasaur's line: %+v", got.Evolutions)
+	}
+}
+
+// The committed evolutions.json must be what the extractor produces, so
+// that rerunning it does not undo reviewed decisions.
+func TestExtractSeed(t *testing.T) {
+	pokemons := append(fixture.Dataset(t, "kanto"), fixture.Dataset(t, "johto")...)
+	got := Extract(pokemons).Evolutions
+	want := fixture.Evolutions(t)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extracted %d evolutions that differ from the %d in evolutions.json; run make extract-evolutions", len(got), len(want))
+	}
+	for _, e := range got {
+		if err := e.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+}
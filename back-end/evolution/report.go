@@ -0,0 +1,105 @@
+package evolution
+
+import (
+	"GO-Mongo/models"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report lists what Extract could not settle on its own.
+type Report struct {
+	Pokemon    int // bios read
+	Evolutions int // evolutions extracted
+
+	// Conflicts are pairs two bios describe with different methods; the
+	// first description is kept.
+	Conflicts []Conflict
+	// Unparsed are evolution sentences that did not fit the grammar.
+	Unparsed []Unparsed
+	// RegionalForms are sentences about regional variants, which are not
+	// extracted.
+	RegionalForms []Unparsed
+	// MissingTrigger are evolutions whose method no bio states; they are
+	// extracted as TriggerOther with no condition.
+	MissingTrigger []models.Evolution
+	// OtherTrigger are evolutions with a method outside the known triggers.
+	OtherTrigger []models.Evolution
+	// OutsideDatasets are Pokemon named in evolutions but missing from the
+	// datasets, e.g. later-generation evolutions.
+	OutsideDatasets []string
+}
+
+// Conflict is one pair described two ways.
+type Conflict struct {
+	Kept, Dropped models.Evolution
+	Source        string // the Pokemon whose bio gave Dropped
+}
+
+// Unparsed is a sentence and the Pokemon whose bio contains it.
+type Unparsed struct {
+	Pokemon  string
+	Sentence string
+}
+
+// WriteMarkdown writes the report as a review checklist.
+func (r Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Evolution extraction review\n\n")
+	fmt.Fprintf(&b, "Extracted %d evolutions from %d bios. Check the items below before importing. Settle them in package evolution (`regionalOnly`, `reviewed`) rather than in `evolutions.json`, which rerunning the extractor overwrites.\n", r.Evolutions, r.Pokemon)
+
+	section(&b, "Conflicting descriptions", len(r.Conflicts), func() {
+		for _, c := range r.Conflicts {
+			fmt.Fprintf(&b, "- [ ] %s → %s: kept %s, %s's bio says %s\n", c.Kept.From, c.Kept.To, describe(c.Kept.EvolutionMethod), c.Source, describe(c.Dropped.EvolutionMethod))
+		}
+	})
+	section(&b, "Sentences not understood", len(r.Unparsed), func() {
+		for _, u := range r.Unparsed {
+			fmt.Fprintf(&b, "- [ ] %s: %q\n", u.Pokemon, u.Sentence)
+		}
+	})
+	section(&b, "Regional forms (not extracted)", len(r.RegionalForms), func() {
+		for _, u := range r.RegionalForms {
+			fmt.Fprintf(&b, "- [ ] %s: %q\n", u.Pokemon, u.Sentence)
+		}
+	})
+	section(&b, "No method stated", len(r.MissingTrigger), func() {
+		for _, e := range r.MissingTrigger {
+			fmt.Fprintf(&b, "- [ ] %s → %s\n", e.From, e.To)
+		}
+	})
+	section(&b, "Unusual methods (trigger \"other\")", len(r.OtherTrigger), func() {
+		for _, e := range r.OtherTrigger {
+			fmt.Fprintf(&b, "- [ ] %s → %s: %s\n", e.From, e.To, e.Condition)
+		}
+	})
+	section(&b, "Pokemon outside the datasets", len(r.OutsideDatasets), func() {
+		fmt.Fprintf(&b, "%s\n", strings.Join(r.OutsideDatasets, ", "))
+	})
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func section(b *strings.Builder, title string, n int, body func()) {
+	fmt.Fprintf(b, "\n## %s (%d)\n\n", title, n)
+	if n == 0 {
+		b.WriteString("Nothing to review.\n")
+		return
+	}
+	body()
+}
+
+func describe(m models.EvolutionMethod) string {
+	parts := []string{m.Trigger}
+	if m.Level > 0 {
+		parts = append(parts, fmt.Sprintf("level %d", m.Level))
+	}
+	if m.Item != "" {
+		parts = append(parts, m.Item)
+	}
+	if m.Condition != "" {
+		parts = append(parts, m.Condition)
+	}
+	return strings.Join(parts, ", ")
+}
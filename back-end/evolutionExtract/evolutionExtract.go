@@ -0,0 +1,90 @@
+// Command evolutionExtract seeds jsonImport/evolutions/evolutions.json from
+// the "It evolves into ..." sentences of the dataset bios, and writes
+// REVIEW.md next to it listing what needs a human decision. Settle those in
+// package evolution so that rerunning this command, which overwrites the
+// JSON, keeps them; REVIEW.md itself is not committed.
+package main
+
+import (
+	"GO-Mongo/config"
+	"GO-Mongo/evolution"
+	"GO-Mongo/logger"
+	"GO-Mongo/models"
+	"encoding/json" // นำเข้า json สำหรับอ่าน dataset และเขียนผลลัพธ์
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// datasets คือไฟล์ที่ใช้อ่าน bio ทุกภูมิภาค
+var datasets = []string{
+	"jsonImport/kanto/pokemon_kanto_dataset.json",
+	"jsonImport/johto/pokemon_johto_dataset.json",
+}
+
+const outDir = "jsonImport/evolutions" // โฟลเดอร์ที่เก็บผลลัพธ์ให้ jsonImport นำเข้า
+
+func main() {
+	cfg, err := config.LoadConfig("env.yaml") // โหลดการตั้งค่า logging จากไฟล์ env.yaml
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+	appLogger, err := logger.New(cfg.Logging)
+	if err != nil {
+		log.Fatal("Failed to create logger:", err)
+	}
+
+	var pokemons []models.Pokemon
+	for _, path := range datasets {
+		loaded, err := load(path)
+		if err != nil {
+			fatal(appLogger, "failed to read dataset", err)
+		}
+		pokemons = append(pokemons, loaded...)
+	}
+
+	result := evolution.Extract(pokemons)
+	for _, e := range result.Evolutions {
+		if err := e.Validate(); err != nil {
+			fatal(appLogger, "extracted an invalid evolution", err) // ไม่ควรเกิด ถ้าเกิดแปลว่า extractor มี bug
+		}
+	}
+
+	data, err := json.MarshalIndent(result.Evolutions, "", "  ")
+	if err != nil {
+		fatal(appLogger, "failed to encode evolutions", err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "evolutions.json"), append(data, '\n'), 0o644); err != nil {
+		fatal(appLogger, "failed to write evolutions", err)
+	}
+
+	report, err := os.Create(filepath.Join(outDir, "REVIEW.md"))
+	if err != nil {
+		fatal(appLogger, "failed to create review report", err)
+	}
+	defer report.Close()
+	if err := result.Report.WriteMarkdown(report); err != nil {
+		fatal(appLogger, "failed to write review report", err)
+	}
+
+	appLogger.Info("extracted evolutions", "evolutions", len(result.Evolutions), "bios", len(pokemons), "report", report.Name())
+}
+
+func fatal(l *slog.Logger, msg string, err error) {
+	l.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+func load(path string) ([]models.Pokemon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pokemons []models.Pokemon
+	if err := json.Unmarshal(data, &pokemons); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return pokemons, nil
+}
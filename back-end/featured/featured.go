@@ -0,0 +1,48 @@
+// Package featured picks the Pokemon of the day: the same one for everyone
+// on a given calendar date, without storing anything.
+package featured
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	// The runtime image has no zoneinfo; embed it so any IANA time zone
+	// resolves.
+	_ "time/tzdata"
+)
+
+// seed makes the order of the picks specific to this service rather than
+// the same as any other PCG-seeded shuffle.
+const seed = "pokedex-daily"
+
+// Day returns the calendar date of t in t's location as a number of days
+// since 1 January 1970, so every instant of that date maps to the same day.
+func Day(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// Index returns which of n items, in a fixed order, is featured on day. Days
+// are grouped in cycles of n, and each cycle features every item once in a
+// shuffled order, so an item does not come back before all the others have
+// been featured. It returns -1 when n is 0.
+func Index(n int, day int64) int {
+	if n <= 0 {
+		return -1
+	}
+	cycle, pos := day/int64(n), day%int64(n)
+	if pos < 0 {
+		cycle, pos = cycle-1, pos+int64(n)
+	}
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	r := rand.New(rand.NewPCG(h.Sum64(), uint64(cycle)))
+	return r.Perm(n)[pos]
+}
+
+// Until returns how long remains of t's calendar date in t's location.
+func Until(t time.Time) time.Duration {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location()).Sub(t)
+}
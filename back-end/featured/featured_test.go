@@ -0,0 +1,50 @@
+package featured
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDayFollowsLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	instant := time.Date(2026, time.October, 18, 20, 0, 0, 0, time.UTC)
+	if got, want := Day(instant.In(tokyo))-Day(instant), int64(1); got != want {
+		t.Errorf("Tokyo is %d days ahead of UTC at 20:00 UTC, want %d", got, want)
+	}
+	morning := time.Date(2026, time.October, 18, 0, 0, 1, 0, tokyo)
+	night := time.Date(2026, time.October, 18, 23, 59, 59, 0, tokyo)
+	if Day(morning) != Day(night) {
+		t.Error("the start and end of a date are different days")
+	}
+}
+
+func TestIndexCoversEveryItemPerCycle(t *testing.T) {
+	const n = 7
+	for cycle := int64(-2); cycle < 3; cycle++ {
+		var seen []int
+		for day := cycle * n; day < (cycle+1)*n; day++ {
+			seen = append(seen, Index(n, day))
+		}
+		slices.Sort(seen)
+		if !slices.Equal(seen, []int{0, 1, 2, 3, 4, 5, 6}) {
+			t.Errorf("cycle %d features %v, want each item once", cycle, seen)
+		}
+	}
+	if Index(n, 100) != Index(n, 100) {
+		t.Error("Index is not deterministic")
+	}
+	if Index(0, 100) != -1 {
+		t.Error("Index(0) != -1")
+	}
+}
+
+func TestUntil(t *testing.T) {
+	at := time.Date(2026, time.October, 18, 22, 30, 0, 0, time.UTC)
+	if got := Until(at); got != 90*time.Minute {
+		t.Errorf("Until = %v, want 1h30m", got)
+	}
+}
@@ -0,0 +1,930 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pokedex/v1/pokedex.proto
+
+package pokedexv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Pokemon struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// National dex number, e.g. 25.
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Dex number as stored in the dataset, e.g. "#0025".
+	DexNumber string `protobuf:"bytes,2,opt,name=dex_number,json=dexNumber,proto3" json:"dex_number,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// One or two types, primary first.
+	Types []string `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	// Regular abilities, without the hidden ability.
+	Abilities     []string   `protobuf:"bytes,5,rep,name=abilities,proto3" json:"abilities,omitempty"`
+	HiddenAbility string     `protobuf:"bytes,6,opt,name=hidden_ability,json=hiddenAbility,proto3" json:"hidden_ability,omitempty"`
+	IsLegendary   bool       `protobuf:"varint,7,opt,name=is_legendary,json=isLegendary,proto3" json:"is_legendary,omitempty"`
+	Bio           string     `protobuf:"bytes,8,opt,name=bio,proto3" json:"bio,omitempty"`
+	BaseStats     *BaseStats `protobuf:"bytes,9,opt,name=base_stats,json=baseStats,proto3" json:"base_stats,omitempty"`
+	// One or two egg groups; empty when the dataset has none.
+	EggGroups     []string `protobuf:"bytes,10,rep,name=egg_groups,json=eggGroups,proto3" json:"egg_groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pokemon) Reset() {
+	*x = Pokemon{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokemon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokemon) ProtoMessage() {}
+
+func (x *Pokemon) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokemon.ProtoReflect.Descriptor instead.
+func (*Pokemon) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pokemon) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pokemon) GetDexNumber() string {
+	if x != nil {
+		return x.DexNumber
+	}
+	return ""
+}
+
+func (x *Pokemon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pokemon) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Pokemon) GetAbilities() []string {
+	if x != nil {
+		return x.Abilities
+	}
+	return nil
+}
+
+func (x *Pokemon) GetHiddenAbility() string {
+	if x != nil {
+		return x.HiddenAbility
+	}
+	return ""
+}
+
+func (x *Pokemon) GetIsLegendary() bool {
+	if x != nil {
+		return x.IsLegendary
+	}
+	return false
+}
+
+func (x *Pokemon) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *Pokemon) GetBaseStats() *BaseStats {
+	if x != nil {
+		return x.BaseStats
+	}
+	return nil
+}
+
+func (x *Pokemon) GetEggGroups() []string {
+	if x != nil {
+		return x.EggGroups
+	}
+	return nil
+}
+
+// PokemonList is the protobuf body of the REST list routes.
+type PokemonList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       []*Pokemon             `protobuf:"bytes,1,rep,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PokemonList) Reset() {
+	*x = PokemonList{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PokemonList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PokemonList) ProtoMessage() {}
+
+func (x *PokemonList) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PokemonList.ProtoReflect.Descriptor instead.
+func (*PokemonList) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PokemonList) GetPokemon() []*Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type BaseStats struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Hp        int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	Attack    int32                  `protobuf:"varint,2,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense   int32                  `protobuf:"varint,3,opt,name=defense,proto3" json:"defense,omitempty"`
+	SpAttack  int32                  `protobuf:"varint,4,opt,name=sp_attack,json=spAttack,proto3" json:"sp_attack,omitempty"`
+	SpDefense int32                  `protobuf:"varint,5,opt,name=sp_defense,json=spDefense,proto3" json:"sp_defense,omitempty"`
+	Speed     int32                  `protobuf:"varint,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	// Sum of the six stats.
+	Total         int32 `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BaseStats) Reset() {
+	*x = BaseStats{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BaseStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaseStats) ProtoMessage() {}
+
+func (x *BaseStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaseStats.ProtoReflect.Descriptor instead.
+func (*BaseStats) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BaseStats) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *BaseStats) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *BaseStats) GetDefense() int32 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *BaseStats) GetSpAttack() int32 {
+	if x != nil {
+		return x.SpAttack
+	}
+	return 0
+}
+
+func (x *BaseStats) GetSpDefense() int32 {
+	if x != nil {
+		return x.SpDefense
+	}
+	return 0
+}
+
+func (x *BaseStats) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *BaseStats) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Lookup:
+	//
+	//	*GetRequest_Id
+	//	*GetRequest_Name
+	Lookup        isGetRequest_Lookup `protobuf_oneof:"lookup"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRequest) GetLookup() isGetRequest_Lookup {
+	if x != nil {
+		return x.Lookup
+	}
+	return nil
+}
+
+func (x *GetRequest) GetId() int32 {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *GetRequest) GetName() string {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetRequest_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+type isGetRequest_Lookup interface {
+	isGetRequest_Lookup()
+}
+
+type GetRequest_Id struct {
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type GetRequest_Name struct {
+	// Exact name, matched case-insensitively.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+func (*GetRequest_Id) isGetRequest_Lookup() {}
+
+func (*GetRequest_Name) isGetRequest_Lookup() {}
+
+type GetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       *Pokemon               `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetResponse) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LegendaryOnly bool                   `protobuf:"varint,1,opt,name=legendary_only,json=legendaryOnly,proto3" json:"legendary_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRequest) GetLegendaryOnly() bool {
+	if x != nil {
+		return x.LegendaryOnly
+	}
+	return false
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       *Pokemon               `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListResponse) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Substring of the name or dex number.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Type in either slot, e.g. "Fire".
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Legendary     *bool  `protobuf:"varint,3,opt,name=legendary,proto3,oneof" json:"legendary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLegendary() bool {
+	if x != nil && x.Legendary != nil {
+		return *x.Legendary
+	}
+	return false
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       []*Pokemon             `protobuf:"bytes,1,rep,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchResponse) GetPokemon() []*Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type GetTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTypesRequest) Reset() {
+	*x = GetTypesRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTypesRequest) ProtoMessage() {}
+
+func (x *GetTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTypesRequest.ProtoReflect.Descriptor instead.
+func (*GetTypesRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{9}
+}
+
+type GetTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTypesResponse) Reset() {
+	*x = GetTypesResponse{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTypesResponse) ProtoMessage() {}
+
+func (x *GetTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTypesResponse.ProtoReflect.Descriptor instead.
+func (*GetTypesResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTypesResponse) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{11}
+}
+
+type GetStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalPokemon   int64                  `protobuf:"varint,1,opt,name=total_pokemon,json=totalPokemon,proto3" json:"total_pokemon,omitempty"`
+	LegendaryCount int64                  `protobuf:"varint,2,opt,name=legendary_count,json=legendaryCount,proto3" json:"legendary_count,omitempty"`
+	// Pokemon per type; dual-type Pokemon count under both types.
+	TypeDistribution map[string]int64 `protobuf:"bytes,3,rep,name=type_distribution,json=typeDistribution,proto3" json:"type_distribution,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_v1_pokedex_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_v1_pokedex_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetStatsResponse) GetTotalPokemon() int64 {
+	if x != nil {
+		return x.TotalPokemon
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetLegendaryCount() int64 {
+	if x != nil {
+		return x.LegendaryCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetTypeDistribution() map[string]int64 {
+	if x != nil {
+		return x.TypeDistribution
+	}
+	return nil
+}
+
+var File_pokedex_v1_pokedex_proto protoreflect.FileDescriptor
+
+const file_pokedex_v1_pokedex_proto_rawDesc = "" +
+	"\n" +
+	"\x18pokedex/v1/pokedex.proto\x12\n" +
+	"pokedex.v1\"\xb1\x02\n" +
+	"\aPokemon\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"dex_number\x18\x02 \x01(\tR\tdexNumber\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x1c\n" +
+	"\tabilities\x18\x05 \x03(\tR\tabilities\x12%\n" +
+	"\x0ehidden_ability\x18\x06 \x01(\tR\rhiddenAbility\x12!\n" +
+	"\fis_legendary\x18\a \x01(\bR\visLegendary\x12\x10\n" +
+	"\x03bio\x18\b \x01(\tR\x03bio\x124\n" +
+	"\n" +
+	"base_stats\x18\t \x01(\v2\x15.pokedex.v1.BaseStatsR\tbaseStats\x12\x1d\n" +
+	"\n" +
+	"egg_groups\x18\n" +
+	" \x03(\tR\teggGroups\"<\n" +
+	"\vPokemonList\x12-\n" +
+	"\apokemon\x18\x01 \x03(\v2\x13.pokedex.v1.PokemonR\apokemon\"\xb5\x01\n" +
+	"\tBaseStats\x12\x0e\n" +
+	"\x02hp\x18\x01 \x01(\x05R\x02hp\x12\x16\n" +
+	"\x06attack\x18\x02 \x01(\x05R\x06attack\x12\x18\n" +
+	"\adefense\x18\x03 \x01(\x05R\adefense\x12\x1b\n" +
+	"\tsp_attack\x18\x04 \x01(\x05R\bspAttack\x12\x1d\n" +
+	"\n" +
+	"sp_defense\x18\x05 \x01(\x05R\tspDefense\x12\x14\n" +
+	"\x05speed\x18\x06 \x01(\x05R\x05speed\x12\x14\n" +
+	"\x05total\x18\a \x01(\x05R\x05total\">\n" +
+	"\n" +
+	"GetRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x05H\x00R\x02id\x12\x14\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04nameB\b\n" +
+	"\x06lookup\"<\n" +
+	"\vGetResponse\x12-\n" +
+	"\apokemon\x18\x01 \x01(\v2\x13.pokedex.v1.PokemonR\apokemon\"4\n" +
+	"\vListRequest\x12%\n" +
+	"\x0elegendary_only\x18\x01 \x01(\bR\rlegendaryOnly\"=\n" +
+	"\fListResponse\x12-\n" +
+	"\apokemon\x18\x01 \x01(\v2\x13.pokedex.v1.PokemonR\apokemon\"j\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\tlegendary\x18\x03 \x01(\bH\x00R\tlegendary\x88\x01\x01B\f\n" +
+	"\n" +
+	"_legendary\"?\n" +
+	"\x0eSearchResponse\x12-\n" +
+	"\apokemon\x18\x01 \x03(\v2\x13.pokedex.v1.PokemonR\apokemon\"\x11\n" +
+	"\x0fGetTypesRequest\"(\n" +
+	"\x10GetTypesResponse\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\"\x11\n" +
+	"\x0fGetStatsRequest\"\x86\x02\n" +
+	"\x10GetStatsResponse\x12#\n" +
+	"\rtotal_pokemon\x18\x01 \x01(\x03R\ftotalPokemon\x12'\n" +
+	"\x0flegendary_count\x18\x02 \x01(\x03R\x0elegendaryCount\x12_\n" +
+	"\x11type_distribution\x18\x03 \x03(\v22.pokedex.v1.GetStatsResponse.TypeDistributionEntryR\x10typeDistribution\x1aC\n" +
+	"\x15TypeDistributionEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x012\xd4\x02\n" +
+	"\x0ePokedexService\x126\n" +
+	"\x03Get\x12\x16.pokedex.v1.GetRequest\x1a\x17.pokedex.v1.GetResponse\x12;\n" +
+	"\x04List\x12\x17.pokedex.v1.ListRequest\x1a\x18.pokedex.v1.ListResponse0\x01\x12?\n" +
+	"\x06Search\x12\x19.pokedex.v1.SearchRequest\x1a\x1a.pokedex.v1.SearchResponse\x12E\n" +
+	"\bGetTypes\x12\x1b.pokedex.v1.GetTypesRequest\x1a\x1c.pokedex.v1.GetTypesResponse\x12E\n" +
+	"\bGetStats\x12\x1b.pokedex.v1.GetStatsRequest\x1a\x1c.pokedex.v1.GetStatsResponseB#Z!GO-Mongo/gen/pokedex/v1;pokedexv1b\x06proto3"
+
+var (
+	file_pokedex_v1_pokedex_proto_rawDescOnce sync.Once
+	file_pokedex_v1_pokedex_proto_rawDescData []byte
+)
+
+func file_pokedex_v1_pokedex_proto_rawDescGZIP() []byte {
+	file_pokedex_v1_pokedex_proto_rawDescOnce.Do(func() {
+		file_pokedex_v1_pokedex_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pokedex_v1_pokedex_proto_rawDesc), len(file_pokedex_v1_pokedex_proto_rawDesc)))
+	})
+	return file_pokedex_v1_pokedex_proto_rawDescData
+}
+
+var file_pokedex_v1_pokedex_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_pokedex_v1_pokedex_proto_goTypes = []any{
+	(*Pokemon)(nil),          // 0: pokedex.v1.Pokemon
+	(*PokemonList)(nil),      // 1: pokedex.v1.PokemonList
+	(*BaseStats)(nil),        // 2: pokedex.v1.BaseStats
+	(*GetRequest)(nil),       // 3: pokedex.v1.GetRequest
+	(*GetResponse)(nil),      // 4: pokedex.v1.GetResponse
+	(*ListRequest)(nil),      // 5: pokedex.v1.ListRequest
+	(*ListResponse)(nil),     // 6: pokedex.v1.ListResponse
+	(*SearchRequest)(nil),    // 7: pokedex.v1.SearchRequest
+	(*SearchResponse)(nil),   // 8: pokedex.v1.SearchResponse
+	(*GetTypesRequest)(nil),  // 9: pokedex.v1.GetTypesRequest
+	(*GetTypesResponse)(nil), // 10: pokedex.v1.GetTypesResponse
+	(*GetStatsRequest)(nil),  // 11: pokedex.v1.GetStatsRequest
+	(*GetStatsResponse)(nil), // 12: pokedex.v1.GetStatsResponse
+	nil,                      // 13: pokedex.v1.GetStatsResponse.TypeDistributionEntry
+}
+var file_pokedex_v1_pokedex_proto_depIdxs = []int32{
+	2,  // 0: pokedex.v1.Pokemon.base_stats:type_name -> pokedex.v1.BaseStats
+	0,  // 1: pokedex.v1.PokemonList.pokemon:type_name -> pokedex.v1.Pokemon
+	0,  // 2: pokedex.v1.GetResponse.pokemon:type_name -> pokedex.v1.Pokemon
+	0,  // 3: pokedex.v1.ListResponse.pokemon:type_name -> pokedex.v1.Pokemon
+	0,  // 4: pokedex.v1.SearchResponse.pokemon:type_name -> pokedex.v1.Pokemon
+	13, // 5: pokedex.v1.GetStatsResponse.type_distribution:type_name -> pokedex.v1.GetStatsResponse.TypeDistributionEntry
+	3,  // 6: pokedex.v1.PokedexService.Get:input_type -> pokedex.v1.GetRequest
+	5,  // 7: pokedex.v1.PokedexService.List:input_type -> pokedex.v1.ListRequest
+	7,  // 8: pokedex.v1.PokedexService.Search:input_type -> pokedex.v1.SearchRequest
+	9,  // 9: pokedex.v1.PokedexService.GetTypes:input_type -> pokedex.v1.GetTypesRequest
+	11, // 10: pokedex.v1.PokedexService.GetStats:input_type -> pokedex.v1.GetStatsRequest
+	4,  // 11: pokedex.v1.PokedexService.Get:output_type -> pokedex.v1.GetResponse
+	6,  // 12: pokedex.v1.PokedexService.List:output_type -> pokedex.v1.ListResponse
+	8,  // 13: pokedex.v1.PokedexService.Search:output_type -> pokedex.v1.SearchResponse
+	10, // 14: pokedex.v1.PokedexService.GetTypes:output_type -> pokedex.v1.GetTypesResponse
+	12, // 15: pokedex.v1.PokedexService.GetStats:output_type -> pokedex.v1.GetStatsResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pokedex_v1_pokedex_proto_init() }
+func file_pokedex_v1_pokedex_proto_init() {
+	if File_pokedex_v1_pokedex_proto != nil {
+		return
+	}
+	file_pokedex_v1_pokedex_proto_msgTypes[3].OneofWrappers = []any{
+		(*GetRequest_Id)(nil),
+		(*GetRequest_Name)(nil),
+	}
+	file_pokedex_v1_pokedex_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pokedex_v1_pokedex_proto_rawDesc), len(file_pokedex_v1_pokedex_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pokedex_v1_pokedex_proto_goTypes,
+		DependencyIndexes: file_pokedex_v1_pokedex_proto_depIdxs,
+		MessageInfos:      file_pokedex_v1_pokedex_proto_msgTypes,
+	}.Build()
+	File_pokedex_v1_pokedex_proto = out.File
+	file_pokedex_v1_pokedex_proto_goTypes = nil
+	file_pokedex_v1_pokedex_proto_depIdxs = nil
+}
@@ -0,0 +1,293 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pokedex/v1/pokedex.proto
+
+package pokedexv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PokedexService_Get_FullMethodName      = "/pokedex.v1.PokedexService/Get"
+	PokedexService_List_FullMethodName     = "/pokedex.v1.PokedexService/List"
+	PokedexService_Search_FullMethodName   = "/pokedex.v1.PokedexService/Search"
+	PokedexService_GetTypes_FullMethodName = "/pokedex.v1.PokedexService/GetTypes"
+	PokedexService_GetStats_FullMethodName = "/pokedex.v1.PokedexService/GetStats"
+)
+
+// PokedexServiceClient is the client API for PokedexService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PokedexService is the gRPC read API over the Pokedex dataset. It serves the
+// same data as the REST routes under /api/pokemon.
+type PokedexServiceClient interface {
+	// Get returns one Pokemon by national dex number or by name.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// List streams every Pokemon in dex order.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListResponse], error)
+	// Search returns the Pokemon matching all of the given criteria.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// GetTypes returns the type names present in the dataset.
+	GetTypes(ctx context.Context, in *GetTypesRequest, opts ...grpc.CallOption) (*GetTypesResponse, error)
+	// GetStats returns summary counts over the dataset.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+}
+
+type pokedexServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPokedexServiceClient(cc grpc.ClientConnInterface) PokedexServiceClient {
+	return &pokedexServiceClient{cc}
+}
+
+func (c *pokedexServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, PokedexService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokedexServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PokedexService_ServiceDesc.Streams[0], PokedexService_List_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, ListResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PokedexService_ListClient = grpc.ServerStreamingClient[ListResponse]
+
+func (c *pokedexServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, PokedexService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokedexServiceClient) GetTypes(ctx context.Context, in *GetTypesRequest, opts ...grpc.CallOption) (*GetTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTypesResponse)
+	err := c.cc.Invoke(ctx, PokedexService_GetTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokedexServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, PokedexService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PokedexServiceServer is the server API for PokedexService service.
+// All implementations must embed UnimplementedPokedexServiceServer
+// for forward compatibility.
+//
+// PokedexService is the gRPC read API over the Pokedex dataset. It serves the
+// same data as the REST routes under /api/pokemon.
+type PokedexServiceServer interface {
+	// Get returns one Pokemon by national dex number or by name.
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// List streams every Pokemon in dex order.
+	List(*ListRequest, grpc.ServerStreamingServer[ListResponse]) error
+	// Search returns the Pokemon matching all of the given criteria.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// GetTypes returns the type names present in the dataset.
+	GetTypes(context.Context, *GetTypesRequest) (*GetTypesResponse, error)
+	// GetStats returns summary counts over the dataset.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	mustEmbedUnimplementedPokedexServiceServer()
+}
+
+// UnimplementedPokedexServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPokedexServiceServer struct{}
+
+func (UnimplementedPokedexServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedPokedexServiceServer) List(*ListRequest, grpc.ServerStreamingServer[ListResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedPokedexServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedPokedexServiceServer) GetTypes(context.Context, *GetTypesRequest) (*GetTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTypes not implemented")
+}
+func (UnimplementedPokedexServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedPokedexServiceServer) mustEmbedUnimplementedPokedexServiceServer() {}
+func (UnimplementedPokedexServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePokedexServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PokedexServiceServer will
+// result in compilation errors.
+type UnsafePokedexServiceServer interface {
+	mustEmbedUnimplementedPokedexServiceServer()
+}
+
+func RegisterPokedexServiceServer(s grpc.ServiceRegistrar, srv PokedexServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPokedexServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PokedexService_ServiceDesc, srv)
+}
+
+func _PokedexService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokedexService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PokedexServiceServer).List(m, &grpc.GenericServerStream[ListRequest, ListResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PokedexService_ListServer = grpc.ServerStreamingServer[ListResponse]
+
+func _PokedexService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokedexService_GetTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).GetTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_GetTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).GetTypes(ctx, req.(*GetTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokedexService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokedexServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokedexService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokedexServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PokedexService_ServiceDesc is the grpc.ServiceDesc for PokedexService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PokedexService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pokedex.v1.PokedexService",
+	HandlerType: (*PokedexServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _PokedexService_Get_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _PokedexService_Search_Handler,
+		},
+		{
+			MethodName: "GetTypes",
+			Handler:    _PokedexService_GetTypes_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _PokedexService_GetStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _PokedexService_List_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pokedex/v1/pokedex.proto",
+}
@@ -0,0 +1,82 @@
+package gql
+
+import (
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ability is an ability name; who has it is looked up in the request's
+// ability index when asked for.
+type ability struct {
+	name    string
+	regular []int // dex numbers with it as ability_01 or ability_02
+	hidden  []int // dex numbers with it as hidden_ability
+}
+
+// abilityIndex is built at most once per request from the region lists.
+type abilityIndex struct {
+	once  sync.Once
+	index map[string]*ability // keyed by lower-case name
+	err   error
+}
+
+func (s *Service) abilityIndex(ctx context.Context) (map[string]*ability, error) {
+	idx := loadersFrom(ctx).abilities
+	idx.once.Do(func() {
+		all, err := search(ctx, s.regions, repository.SearchFilter{})
+		if err != nil {
+			idx.err = err
+			return
+		}
+		idx.index = map[string]*ability{}
+		add := func(name string, number int, hidden bool) {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return
+			}
+			key := strings.ToLower(name)
+			a, ok := idx.index[key]
+			if !ok {
+				a = &ability{name: name}
+				idx.index[key] = a
+			}
+			if hidden {
+				a.hidden = append(a.hidden, number)
+			} else {
+				a.regular = append(a.regular, number)
+			}
+		}
+		for _, n := range all {
+			number := n.Number()
+			add(n.Ability01, number, false)
+			add(n.Ability02, number, false)
+			add(n.HiddenAbility, number, true)
+		}
+	})
+	return idx.index, idx.err
+}
+
+// abilityHolders returns a thunk loading the Pokemon that have the ability,
+// batched with every other Pokemon lookup at the same depth.
+func (s *Service) abilityHolders(ctx context.Context, name string, hidden bool) (any, error) {
+	index, err := s.abilityIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a, ok := index[strings.ToLower(name)]
+	if !ok {
+		return []*node{}, nil
+	}
+	numbers := a.regular
+	if hidden {
+		numbers = a.hidden
+	}
+	return loadersFrom(ctx).pokemon.LoadMany(ctx, numbers), nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, repository.ErrNotFound)
+}
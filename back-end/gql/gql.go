@@ -0,0 +1,110 @@
+// Package gql serves the Pokedex over GraphQL. Clients select exactly the
+// fields they render and can fetch lists, types and aggregates in one round
+// trip. Pokemon referenced from other objects are loaded through a
+// per-request batching loader so a query touching many of them issues one
+// repository call per region and depth instead of one per Pokemon.
+package gql
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/logger"
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Region is one regional dataset, e.g. kanto, served by its own repository.
+type Region struct {
+	Name    string
+	Pokemon repository.PokemonRepository
+}
+
+// Request is the standard GraphQL-over-HTTP request body.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Service executes GraphQL requests against a fixed set of regions.
+type Service struct {
+	schema  graphql.Schema
+	regions []Region
+}
+
+// New builds the schema over regions, which are listed in the given order.
+func New(regions []Region) (*Service, error) {
+	if len(regions) == 0 {
+		return nil, errors.New("gql: at least one region is required")
+	}
+	s := &Service{regions: regions}
+	schema, err := s.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs req. Errors are reported in the result, never returned.
+func (s *Service) Execute(ctx context.Context, req Request) *graphql.Result {
+	return s.run(withLoaders(ctx, s.regions), req)
+}
+
+// run executes req with the loaders already in ctx. It is graphql.Do with
+// the request limits of limits.go added to validation.
+func (s *Service) run(ctx context.Context, req Request) *graphql.Result {
+	if len(req.Query) > maxQueryBytes {
+		message := fmt.Sprintf("Query is %d bytes, more than the limit of %d", len(req.Query), maxQueryBytes)
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(limitError(nil, message))}}
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if v := graphql.ValidateDocument(&s.schema, doc, validationRules); !v.IsValid {
+		return &graphql.Result{Errors: v.Errors}
+	}
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// resolverError carries the API error code into the GraphQL error's
+// extensions, the same code a REST client would see in problem+json.
+type resolverError struct {
+	e *apierror.Error
+}
+
+func (r resolverError) Error() string { return r.e.Message }
+
+func (r resolverError) Extensions() map[string]any {
+	ext := map[string]any{"code": r.e.Code}
+	if len(r.e.Details) > 0 {
+		ext["details"] = r.e.Details
+	}
+	return ext
+}
+
+// fail converts err for the client and logs server-side failures.
+func fail(ctx context.Context, err error) error {
+	e := apierror.From(err)
+	if e.Status >= http.StatusInternalServerError {
+		logger.FromContext(ctx).Error(e.Message, "code", e.Code, "status", e.Status, "error", e.Err)
+	}
+	return resolverError{e}
+}
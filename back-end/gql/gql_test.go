@@ -0,0 +1,176 @@
+package gql
+
+import (
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// countingRepository counts the batched lookups and searches made through
+// it.
+type countingRepository struct {
+	repository.PokemonRepository
+
+	mu       sync.Mutex
+	calls    int
+	searches int
+}
+
+func (r *countingRepository) Search(ctx context.Context, filter repository.SearchFilter) ([]models.Pokemon, error) {
+	r.mu.Lock()
+	r.searches++
+	r.mu.Unlock()
+	return r.PokemonRepository.Search(ctx, filter)
+}
+
+func (r *countingRepository) GetByDexNumbers(ctx context.Context, numbers []int) ([]models.Pokemon, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+	return r.PokemonRepository.GetByDexNumbers(ctx, numbers)
+}
+
+func TestLoaderBatchesPerDepth(t *testing.T) {
+	kanto := &countingRepository{PokemonRepository: repository.NewMemory(fixture.Dataset(t, "kanto"))}
+	johto := &countingRepository{PokemonRepository: repository.NewMemory(fixture.Dataset(t, "johto"))}
+	s, err := New([]Region{{Name: "kanto", Pokemon: kanto}, {Name: "johto", Pokemon: johto}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		batches int // one per depth that loads Pokemon not loaded yet
+	}{
+		{"list of ids", `{ pokemonByIds(ids: [1, 4, 7, 25, 152, 155, 158]) { name } }`, 1},
+		{"holders of every ability", `{ abilities { name pokemon { name } hiddenFor { name } } }`, 1},
+		// The second depth only asks for Pokemon the first one loaded.
+		{"nested holders from the cache", `{ abilities { pokemon { abilities { pokemon { name } } } } }`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kanto.calls, johto.calls = 0, 0
+			ctx := withLoaders(context.Background(), s.regions)
+			result := s.run(ctx, Request{Query: tt.query})
+			if result.HasErrors() {
+				t.Fatalf("errors: %v", result.Errors)
+			}
+			if got := loadersFrom(ctx).pokemon.batches; got != tt.batches {
+				t.Errorf("loader made %d batches, want %d", got, tt.batches)
+			}
+			// Each batch asks every region once.
+			if kanto.calls != tt.batches || johto.calls != tt.batches {
+				t.Errorf("GetByDexNumbers calls = %d (kanto), %d (johto), want %d each", kanto.calls, johto.calls, tt.batches)
+			}
+		})
+	}
+}
+
+func TestTypesSearchOncePerRegion(t *testing.T) {
+	kanto := &countingRepository{PokemonRepository: repository.NewMemory(fixture.Dataset(t, "kanto"))}
+	johto := &countingRepository{PokemonRepository: repository.NewMemory(fixture.Dataset(t, "johto"))}
+	s, err := New([]Region{{Name: "kanto", Pokemon: kanto}, {Name: "johto", Pokemon: johto}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := s.Execute(context.Background(), Request{Query: `{
+		types { name count pokemon(first: 2) { totalCount nodes { name } } }
+		regions { types { name count pokemon(first: 1) { nodes { name } } } }
+	}`})
+	if result.HasErrors() {
+		t.Fatalf("errors: %v", result.Errors)
+	}
+	if kanto.searches != 1 || johto.searches != 1 {
+		t.Errorf("Search calls = %d (kanto), %d (johto), want 1 each", kanto.searches, johto.searches)
+	}
+
+	// The counts match a search by type.
+	grass := func(r *countingRepository) int {
+		found, err := r.PokemonRepository.Search(context.Background(), repository.SearchFilter{Type: "grass"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(found)
+	}
+	data := result.Data.(map[string]any)
+	for _, v := range data["types"].([]any) {
+		typ := v.(map[string]any)
+		total := typ["pokemon"].(map[string]any)["totalCount"]
+		if want := grass(kanto) + grass(johto); typ["name"] == "Grass" && (typ["count"] != want || total != want) {
+			t.Errorf("Grass count = %v, totalCount = %v, want %d", typ["count"], total, want)
+		}
+	}
+	for _, v := range data["regions"].([]any)[1].(map[string]any)["types"].([]any) {
+		typ := v.(map[string]any)
+		if want := grass(johto); typ["name"] == "Grass" && typ["count"] != want {
+			t.Errorf("Johto Grass count = %v, want %d", typ["count"], want)
+		}
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	s, err := New([]Region{{Name: "kanto", Pokemon: repository.NewMemory(fixture.Dataset(t, "kanto"))}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// nest selects a name n levels deep through abilities and their
+	// holders.
+	nest := func(n int) string {
+		q, depth := "name", 1
+		for ; depth+2 <= n; depth += 2 {
+			q = "abilities { pokemon { " + q + " } }"
+		}
+		if depth < n {
+			return "{ pokemon(id: 1) { " + q + " } }"
+		}
+		return "{ " + q + " }"
+	}
+	var aliases strings.Builder
+	aliases.WriteString("fragment F on Pokemon { name id }\n{ ")
+	for i := range 300 {
+		aliases.WriteString("p" + strconv.Itoa(i) + ": pokemon(id: 1) { ...F } ")
+	}
+	aliases.WriteString("}")
+
+	tests := []struct {
+		name  string
+		query string
+		want  string // substring of the only error; empty for success
+	}{
+		{"deep enough", nest(10), ""},
+		{"too deep", nest(11), "nested 11 levels deep"},
+		{"too deep through a fragment", "fragment A on Ability { pokemon { abilities { pokemon { abilities { pokemon { name } } } } } }\n{ abilities { pokemon { abilities { pokemon { abilities { ...A } } } } } }", "nested 11 levels deep"},
+		{"too many fields", aliases.String(), "selects 900 fields"},
+		{"too long", "{ " + strings.Repeat(" ", maxQueryBytes) + "regions { name } }", "more than the limit of 32768"},
+		{"introspection", testutil.IntrospectionQuery, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Execute(context.Background(), Request{Query: tt.query})
+			if tt.want == "" {
+				if result.HasErrors() {
+					t.Fatalf("errors: %v", result.Errors)
+				}
+				return
+			}
+			if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, tt.want) {
+				t.Fatalf("errors = %v, want one containing %q", result.Errors, tt.want)
+			}
+			if result.Data != nil {
+				t.Errorf("data = %v, want none: the query must not run", result.Data)
+			}
+			if code := fmt.Sprint(result.Errors[0].Extensions["code"]); code != "invalid_input" {
+				t.Errorf("code = %v, want invalid_input", code)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package gql
+
+import (
+	"GO-Mongo/apierror"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// Limits on a single request. Pokemon and abilities reference each other, so
+// without them one small query can nest or alias its way to millions of
+// resolver calls.
+const (
+	maxQueryBytes  = 32 << 10
+	maxQueryDepth  = 10
+	maxQueryFields = 500
+)
+
+// validationRules are the spec's rules plus limitQuery.
+var validationRules = append(append([]graphql.ValidationRuleFn{}, graphql.SpecifiedRules...), limitQuery)
+
+// limitQuery rejects operations nested deeper than maxQueryDepth or selecting
+// more than maxQueryFields fields, counting every fragment spread as the
+// fields it expands to. Introspection is bounded by the schema, so
+// selections under __schema and __type are not counted.
+func limitQuery(ctx *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+	m := measurer{ctx: ctx, fragments: map[string]size{}, onPath: map[string]bool{}}
+	return &graphql.ValidationRuleInstance{
+		VisitorOpts: &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.OperationDefinition: {
+					Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+						op, ok := p.Node.(*ast.OperationDefinition)
+						if !ok || op == nil {
+							return visitor.ActionSkip, nil
+						}
+						got := m.measure(op.SelectionSet)
+						if got.depth > maxQueryDepth {
+							ctx.ReportError(limitError(op, fmt.Sprintf("Query is nested %d levels deep, more than the limit of %d", got.depth, maxQueryDepth)))
+						}
+						if got.fields > maxQueryFields {
+							ctx.ReportError(limitError(op, fmt.Sprintf("Query selects %d fields, more than the limit of %d", got.fields, maxQueryFields)))
+						}
+						return visitor.ActionSkip, nil
+					},
+				},
+			},
+		},
+	}
+}
+
+// size is the depth and field count of a selection set.
+type size struct {
+	depth, fields int
+}
+
+type measurer struct {
+	ctx       *graphql.ValidationContext
+	fragments map[string]size // measured fragments, so each is walked once
+	onPath    map[string]bool // fragments being walked; a cycle is another rule's error
+}
+
+func (m measurer) measure(set *ast.SelectionSet) size {
+	var out size
+	if set == nil {
+		return out
+	}
+	add := func(s size) {
+		out.depth = max(out.depth, s.depth)
+		out.fields += s.fields
+	}
+	for _, sel := range set.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			s := size{depth: 1, fields: 1}
+			if sel.Name == nil || !strings.HasPrefix(sel.Name.Value, "__") {
+				child := m.measure(sel.SelectionSet)
+				s.depth += child.depth
+				s.fields += child.fields
+			}
+			add(s)
+		case *ast.InlineFragment:
+			add(m.measure(sel.SelectionSet))
+		case *ast.FragmentSpread:
+			if sel.Name == nil {
+				continue
+			}
+			name := sel.Name.Value
+			if s, ok := m.fragments[name]; ok {
+				add(s)
+				continue
+			}
+			frag := m.ctx.Fragment(name)
+			if frag == nil || m.onPath[name] {
+				continue
+			}
+			m.onPath[name] = true
+			s := m.measure(frag.SelectionSet)
+			delete(m.onPath, name)
+			m.fragments[name] = s
+			add(s)
+		}
+	}
+	return out
+}
+
+// limitError is a validation error carrying the invalid_input code in its
+// extensions, like the resolvers' errors.
+func limitError(node ast.Node, message string) *gqlerrors.Error {
+	var nodes []ast.Node
+	if node != nil {
+		nodes = []ast.Node{node}
+	}
+	return gqlerrors.NewError(message, nodes, "", nil, []int{}, resolverError{apierror.InvalidInput(message)})
+}
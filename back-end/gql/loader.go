@@ -0,0 +1,132 @@
+package gql
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"sync"
+)
+
+// node is a Pokemon together with the region it was loaded from.
+type node struct {
+	models.Pokemon
+	Region string
+}
+
+type loadersKey struct{}
+
+// loaders holds the per-request caches shared by all resolvers.
+type loaders struct {
+	pokemon   *pokemonLoader
+	abilities *abilityIndex
+	types     *typeIndex
+}
+
+func withLoaders(ctx context.Context, regions []Region) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{pokemon: newPokemonLoader(regions), abilities: &abilityIndex{}, types: &typeIndex{}})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*loaders); ok {
+		return l
+	}
+	// Resolvers called outside Execute still work, just without sharing.
+	return &loaders{pokemon: newPokemonLoader(nil), abilities: &abilityIndex{}, types: &typeIndex{}}
+}
+
+// pokemonLoader batches lookups by dex number. Load only records the key and
+// returns a thunk; the executor creates every thunk of one depth before
+// calling any, so the first call fetches all recorded keys at once.
+type pokemonLoader struct {
+	regions []Region
+
+	mu      sync.Mutex
+	pending []int
+	done    map[int]*node // nil value: looked up and not found
+	failed  map[int]error
+	batches int // number of fetches, checked by the tests
+}
+
+func newPokemonLoader(regions []Region) *pokemonLoader {
+	return &pokemonLoader{regions: regions, done: map[int]*node{}, failed: map[int]error{}}
+}
+
+// Load returns a thunk resolving to the *node for number, or nil if no
+// region has it.
+func (l *pokemonLoader) Load(ctx context.Context, number int) func() (any, error) {
+	l.mu.Lock()
+	if _, ok := l.done[number]; !ok {
+		l.pending = append(l.pending, number)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.dispatch(ctx)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if err, ok := l.failed[number]; ok {
+			return nil, fail(ctx, err)
+		}
+		if n := l.done[number]; n != nil {
+			return n, nil
+		}
+		return nil, nil
+	}
+}
+
+// LoadMany is Load for a list; numbers that are not found are dropped.
+func (l *pokemonLoader) LoadMany(ctx context.Context, numbers []int) func() (any, error) {
+	thunks := make([]func() (any, error), len(numbers))
+	for i, n := range numbers {
+		thunks[i] = l.Load(ctx, n)
+	}
+	return func() (any, error) {
+		out := make([]*node, 0, len(thunks))
+		for _, thunk := range thunks {
+			v, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if n, ok := v.(*node); ok && n != nil {
+				out = append(out, n)
+			}
+		}
+		return out, nil
+	}
+}
+
+// dispatch fetches every pending key with one GetByDexNumbers per region.
+func (l *pokemonLoader) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var keys []int
+	seen := make(map[int]bool, len(l.pending))
+	for _, n := range l.pending {
+		if _, ok := l.done[n]; !ok && !seen[n] {
+			seen[n] = true
+			keys = append(keys, n)
+		}
+	}
+	l.pending = nil
+	if len(keys) == 0 {
+		return
+	}
+	l.batches++
+
+	for _, n := range keys {
+		l.done[n] = nil
+	}
+	for _, r := range l.regions {
+		found, err := r.Pokemon.GetByDexNumbers(ctx, keys)
+		if err != nil {
+			for _, n := range keys {
+				l.failed[n] = err
+			}
+			return
+		}
+		for _, p := range found {
+			l.done[p.Number()] = &node{Pokemon: p, Region: r.Name}
+		}
+	}
+}
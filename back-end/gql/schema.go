@@ -0,0 +1,621 @@
+package gql
+
+import (
+	"GO-Mongo/apierror"
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type connection struct {
+	items  []*node
+	offset int
+	total  int
+}
+
+type edge struct {
+	cursor string
+	node   *node
+}
+
+// typeRef is a type name, optionally limited to one region.
+type typeRef struct {
+	name    string
+	regions []Region
+}
+
+// statsRef defers the aggregate work until a field asks for it.
+type statsRef struct {
+	regions []Region
+}
+
+type typeCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+func (s *Service) buildSchema() (graphql.Schema, error) {
+	baseStatsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "BaseStats",
+		Description: "Base stats; total is their sum.",
+		Fields: graphql.Fields{
+			"hp":        statField(func(b models.BaseStats) int { return b.HP }),
+			"attack":    statField(func(b models.BaseStats) int { return b.Attack }),
+			"defense":   statField(func(b models.BaseStats) int { return b.Defense }),
+			"spAttack":  statField(func(b models.BaseStats) int { return b.SpAttack }),
+			"spDefense": statField(func(b models.BaseStats) int { return b.SpDefense }),
+			"speed":     statField(func(b models.BaseStats) int { return b.Speed }),
+			"total":     statField(func(b models.BaseStats) int { return b.Total }),
+		},
+	})
+
+	abilityType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Ability",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*ability).name, nil
+			}},
+		},
+	})
+
+	pokemonType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Pokemon",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "National dex number", Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*node).Number(), nil
+			}},
+			"dexNumber":   pokemonField(graphql.String, func(n *node) any { return n.DexNumber }),
+			"name":        pokemonField(graphql.String, func(n *node) any { return n.Name }),
+			"region":      pokemonField(graphql.String, func(n *node) any { return n.Region }),
+			"types":       pokemonField(graphql.NewList(graphql.NewNonNull(graphql.String)), func(n *node) any { return n.Types() }),
+			"eggGroups":   pokemonField(graphql.NewList(graphql.NewNonNull(graphql.String)), func(n *node) any { return n.EggGroups() }),
+			"isLegendary": pokemonField(graphql.Boolean, func(n *node) any { return n.Legendary() }),
+			"bio":         pokemonField(graphql.String, func(n *node) any { return n.Bio }),
+			"baseStats":   pokemonField(baseStatsType, func(n *node) any { return n.BaseStats() }),
+			"abilities": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(abilityType))),
+				Description: "Regular abilities, without the hidden ability",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					var out []*ability
+					for _, name := range p.Source.(*node).Abilities() {
+						out = append(out, &ability{name: name})
+					}
+					return out, nil
+				},
+			},
+			"hiddenAbility": &graphql.Field{Type: abilityType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				if name := strings.TrimSpace(p.Source.(*node).HiddenAbility); name != "" {
+					return &ability{name: name}, nil
+				}
+				return nil, nil
+			}},
+		},
+	})
+
+	// Ability and Pokemon refer to each other, so these fields are added once
+	// both types exist.
+	abilityType.AddFieldConfig("pokemon", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pokemonType))),
+		Description: "Pokemon with this as a regular ability",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return s.abilityHolders(p.Context, p.Source.(*ability).name, false)
+		},
+	})
+	abilityType.AddFieldConfig("hiddenFor", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pokemonType))),
+		Description: "Pokemon with this as their hidden ability",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return s.abilityHolders(p.Context, p.Source.(*ability).name, true)
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: func(p graphql.ResolveParams) (any, error) {
+				c := p.Source.(*connection)
+				return c.offset+len(c.items) < c.total, nil
+			}},
+			"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*connection).offset > 0, nil
+			}},
+			"startCursor": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+				c := p.Source.(*connection)
+				if len(c.items) == 0 {
+					return nil, nil
+				}
+				return encodeCursor(c.offset), nil
+			}},
+			"endCursor": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+				c := p.Source.(*connection)
+				if len(c.items) == 0 {
+					return nil, nil
+				}
+				return encodeCursor(c.offset + len(c.items) - 1), nil
+			}},
+		},
+	})
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PokemonEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(edge).cursor, nil
+			}},
+			"node": &graphql.Field{Type: graphql.NewNonNull(pokemonType), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(edge).node, nil
+			}},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PokemonConnection",
+		Description: "A page of Pokemon. Pass endCursor as after to fetch the next page.",
+		Fields: graphql.Fields{
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*connection).total, nil
+			}},
+			"nodes": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pokemonType))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*connection).items, nil
+			}},
+			"edges": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				c := p.Source.(*connection)
+				edges := make([]edge, len(c.items))
+				for i, n := range c.items {
+					edges[i] = edge{cursor: encodeCursor(c.offset + i), node: n}
+				}
+				return edges, nil
+			}},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source, nil
+			}},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PokemonFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"query":     &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Substring of the name or dex number"},
+			"type":      &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Type in either slot"},
+			"legendary": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	pageArgs := graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize, Description: "Page size, at most 100"},
+		"after": &graphql.ArgumentConfig{Type: graphql.String, Description: "Cursor of the last item of the previous page"},
+	}
+	withPageArgs := func(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		for k, v := range pageArgs {
+			args[k] = v
+		}
+		return args
+	}
+
+	typeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Type",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(typeRef).name, nil
+			}},
+			"count": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Pokemon with this type in either slot", Resolve: func(p graphql.ResolveParams) (any, error) {
+				found, err := s.typeMembers(p.Context, p.Source.(typeRef))
+				return len(found), err
+			}},
+			"pokemon": &graphql.Field{Type: graphql.NewNonNull(connectionType), Args: withPageArgs(graphql.FieldConfigArgument{}), Resolve: func(p graphql.ResolveParams) (any, error) {
+				found, err := s.typeMembers(p.Context, p.Source.(typeRef))
+				if err != nil {
+					return nil, err
+				}
+				return paginate(p.Context, found, p.Args)
+			}},
+		},
+	})
+
+	typeCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TypeCount",
+		Fields: graphql.Fields{
+			"type":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"count": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	statsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stats",
+		Fields: graphql.Fields{
+			"totalPokemon": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (any, error) {
+				sum, err := summary(p.Context, p.Source.(statsRef).regions)
+				return sum.TotalPokemon, err
+			}},
+			"legendaryCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (any, error) {
+				sum, err := summary(p.Context, p.Source.(statsRef).regions)
+				return sum.LegendaryCount, err
+			}},
+			"typeDistribution": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typeCountType))),
+				Description: "Pokemon per type, most common first; dual-type Pokemon count under both types",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sum, err := summary(p.Context, p.Source.(statsRef).regions)
+					if err != nil {
+						return nil, err
+					}
+					out := make([]typeCount, 0, len(sum.TypeDistribution))
+					for t, n := range sum.TypeDistribution {
+						out = append(out, typeCount{Type: t, Count: n})
+					}
+					slices.SortFunc(out, func(a, b typeCount) int {
+						return cmp.Or(b.Count-a.Count, strings.Compare(a.Type, b.Type))
+					})
+					return out, nil
+				},
+			},
+			"averageBaseStats": &graphql.Field{Type: graphql.NewNonNull(baseStatsType), Resolve: func(p graphql.ResolveParams) (any, error) {
+				all, err := search(p.Context, p.Source.(statsRef).regions, repository.SearchFilter{})
+				if err != nil {
+					return nil, err
+				}
+				return averageStats(all), nil
+			}},
+		},
+	})
+
+	regionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Region",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(Region).Name, nil
+			}},
+			"pokemon": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType),
+				Args: withPageArgs(graphql.FieldConfigArgument{"filter": &graphql.ArgumentConfig{Type: filterType}}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.pokemonConnection(p, []Region{p.Source.(Region)})
+				},
+			},
+			"types": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typeType))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return types(p.Context, []Region{p.Source.(Region)})
+			}},
+			"stats": &graphql.Field{Type: graphql.NewNonNull(statsType), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return statsRef{regions: []Region{p.Source.(Region)}}, nil
+			}},
+		},
+	})
+
+	regionArg := &graphql.ArgumentConfig{Type: graphql.String, Description: "Region name; all regions when omitted"}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"pokemon": &graphql.Field{
+				Type:        pokemonType,
+				Description: "One Pokemon by national dex number or by name",
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"name": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: s.resolvePokemon,
+			},
+			"pokemonByIds": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(pokemonType)),
+				Description: "Pokemon by national dex number, null where not found",
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					ids, _ := p.Args["ids"].([]any)
+					if len(ids) > maxPageSize {
+						return nil, fail(p.Context, apierror.InvalidParam("ids", "at most "+strconv.Itoa(maxPageSize)+" ids"))
+					}
+					thunks := make([]func() (any, error), len(ids))
+					for i, v := range ids {
+						id := v.(int)
+						if id < 1 {
+							return nil, fail(p.Context, apierror.InvalidParam("ids", "must be positive dex numbers"))
+						}
+						thunks[i] = loadersFrom(p.Context).pokemon.Load(p.Context, id)
+					}
+					return func() (any, error) {
+						out := make([]any, len(thunks))
+						for i, thunk := range thunks {
+							v, err := thunk()
+							if err != nil {
+								return nil, err
+							}
+							if n, ok := v.(*node); ok && n != nil {
+								out[i] = n
+							}
+						}
+						return out, nil
+					}, nil
+				},
+			},
+			"pokemons": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType),
+				Args: withPageArgs(graphql.FieldConfigArgument{
+					"region": regionArg,
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					regions, err := s.regionsArg(p)
+					if err != nil {
+						return nil, err
+					}
+					return s.pokemonConnection(p, regions)
+				},
+			},
+			"regions": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(regionType))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return s.regions, nil
+			}},
+			"region": &graphql.Field{
+				Type: regionType,
+				Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if r, ok := s.region(p.Args["name"].(string)); ok {
+						return r, nil
+					}
+					return nil, nil
+				},
+			},
+			"types": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typeType))),
+				Args: graphql.FieldConfigArgument{"region": regionArg},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					regions, err := s.regionsArg(p)
+					if err != nil {
+						return nil, err
+					}
+					return types(p.Context, regions)
+				},
+			},
+			"abilities": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(abilityType))),
+				Description: "Abilities found in the datasets, by name",
+				Args:        graphql.FieldConfigArgument{"query": &graphql.ArgumentConfig{Type: graphql.String, Description: "Case-insensitive substring of the name"}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					index, err := s.abilityIndex(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					q, _ := p.Args["query"].(string)
+					q = strings.ToLower(q)
+					out := []*ability{}
+					for _, a := range index {
+						if strings.Contains(strings.ToLower(a.name), q) {
+							out = append(out, a)
+						}
+					}
+					slices.SortFunc(out, func(a, b *ability) int { return strings.Compare(a.name, b.name) })
+					return out, nil
+				},
+			},
+			"ability": &graphql.Field{
+				Type: abilityType,
+				Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					index, err := s.abilityIndex(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					if a, ok := index[strings.ToLower(strings.TrimSpace(p.Args["name"].(string)))]; ok {
+						return a, nil
+					}
+					return nil, nil
+				},
+			},
+			"stats": &graphql.Field{
+				Type: graphql.NewNonNull(statsType),
+				Args: graphql.FieldConfigArgument{"region": regionArg},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					regions, err := s.regionsArg(p)
+					if err != nil {
+						return nil, err
+					}
+					return statsRef{regions: regions}, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// pokemonField is a non-null field read from the source *node.
+func pokemonField(t graphql.Output, get func(*node) any) *graphql.Field {
+	return &graphql.Field{Type: graphql.NewNonNull(t), Resolve: func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(*node)), nil
+	}}
+}
+
+func statField(get func(models.BaseStats) int) *graphql.Field {
+	return &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(models.BaseStats)), nil
+	}}
+}
+
+func (s *Service) resolvePokemon(p graphql.ResolveParams) (any, error) {
+	if id, ok := p.Args["id"].(int); ok {
+		if id < 1 {
+			return nil, fail(p.Context, apierror.InvalidParam("id", "must be a positive dex number"))
+		}
+		return loadersFrom(p.Context).pokemon.Load(p.Context, id), nil
+	}
+	name, _ := p.Args["name"].(string)
+	if strings.TrimSpace(name) == "" {
+		return nil, fail(p.Context, apierror.InvalidInput("Either id or name is required"))
+	}
+	for _, r := range s.regions {
+		found, err := r.Pokemon.GetByName(p.Context, name)
+		if err == nil {
+			return &node{Pokemon: found, Region: r.Name}, nil
+		}
+		if !isNotFound(err) {
+			return nil, fail(p.Context, err)
+		}
+	}
+	return nil, nil
+}
+
+func (s *Service) pokemonConnection(p graphql.ResolveParams, regions []Region) (any, error) {
+	var filter repository.SearchFilter
+	if f, ok := p.Args["filter"].(map[string]any); ok {
+		filter.Query, _ = f["query"].(string)
+		filter.Type, _ = f["type"].(string)
+		if b, ok := f["legendary"].(bool); ok {
+			filter.Legendary = &b
+		}
+	}
+	found, err := search(p.Context, regions, filter)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(p.Context, found, p.Args)
+}
+
+func (s *Service) region(name string) (Region, bool) {
+	for _, r := range s.regions {
+		if strings.EqualFold(r.Name, strings.TrimSpace(name)) {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+// regionsArg resolves the optional region argument.
+func (s *Service) regionsArg(p graphql.ResolveParams) ([]Region, error) {
+	name, ok := p.Args["region"].(string)
+	if !ok {
+		return s.regions, nil
+	}
+	r, ok := s.region(name)
+	if !ok {
+		return nil, fail(p.Context, apierror.InvalidParam("region", "unknown region "+strconv.Quote(name)))
+	}
+	return []Region{r}, nil
+}
+
+// search runs filter against every region, in region order.
+func search(ctx context.Context, regions []Region, filter repository.SearchFilter) ([]*node, error) {
+	var out []*node
+	for _, r := range regions {
+		found, err := r.Pokemon.Search(ctx, filter)
+		if err != nil {
+			return nil, fail(ctx, err)
+		}
+		for _, p := range found {
+			out = append(out, &node{Pokemon: p, Region: r.Name})
+		}
+	}
+	return out, nil
+}
+
+func types(ctx context.Context, regions []Region) ([]typeRef, error) {
+	var names []string
+	for _, r := range regions {
+		found, err := r.Pokemon.Types(ctx)
+		if err != nil {
+			return nil, fail(ctx, err)
+		}
+		names = append(names, found...)
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	out := make([]typeRef, len(names))
+	for i, name := range names {
+		out[i] = typeRef{name: name, regions: regions}
+	}
+	return out, nil
+}
+
+func summary(ctx context.Context, regions []Region) (repository.StatsSummary, error) {
+	sum := repository.StatsSummary{TypeDistribution: map[string]int{}}
+	for _, r := range regions {
+		s, err := r.Pokemon.Stats(ctx)
+		if err != nil {
+			return sum, fail(ctx, err)
+		}
+		sum.TotalPokemon += s.TotalPokemon
+		sum.LegendaryCount += s.LegendaryCount
+		for t, n := range s.TypeDistribution {
+			sum.TypeDistribution[strings.TrimSpace(t)] += n
+		}
+	}
+	return sum, nil
+}
+
+// paginate slices items by the first/after arguments.
+func paginate(ctx context.Context, items []*node, args map[string]any) (*connection, error) {
+	first, _ := args["first"].(int)
+	if first < 0 || first > maxPageSize {
+		return nil, fail(ctx, apierror.InvalidParam("first", "must be between 0 and "+strconv.Itoa(maxPageSize)))
+	}
+	offset := 0
+	if after, ok := args["after"].(string); ok && after != "" {
+		n, ok := decodeCursor(after)
+		if !ok {
+			return nil, fail(ctx, apierror.InvalidParam("after", "is not a cursor returned by this API"))
+		}
+		offset = n + 1
+	}
+	offset = min(offset, len(items))
+	end := min(offset+first, len(items))
+	return &connection{items: items[offset:end], offset: offset, total: len(items)}, nil
+}
+
+// Cursors are opaque to clients; they encode the item's position.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	s, ok := strings.CutPrefix(string(raw), "offset:")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= 0
+}
+
+// averageStats rounds each average to the nearest integer.
+func averageStats(nodes []*node) models.BaseStats {
+	var sum models.BaseStats
+	for _, n := range nodes {
+		b := n.BaseStats()
+		sum.HP += b.HP
+		sum.Attack += b.Attack
+		sum.Defense += b.Defense
+		sum.SpAttack += b.SpAttack
+		sum.SpDefense += b.SpDefense
+		sum.Speed += b.Speed
+		sum.Total += b.Total
+	}
+	if len(nodes) == 0 {
+		return sum
+	}
+	avg := func(total int) int { return (total + len(nodes)/2) / len(nodes) }
+	return models.BaseStats{
+		HP:        avg(sum.HP),
+		Attack:    avg(sum.Attack),
+		Defense:   avg(sum.Defense),
+		SpAttack:  avg(sum.SpAttack),
+		SpDefense: avg(sum.SpDefense),
+		Speed:     avg(sum.Speed),
+		Total:     avg(sum.Total),
+	}
+}
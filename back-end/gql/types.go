@@ -0,0 +1,63 @@
+package gql
+
+import (
+	"GO-Mongo/repository"
+	"context"
+	"strings"
+	"sync"
+)
+
+// typeIndex groups every Pokemon by type. It is built at most once per
+// request, so listing types with their counts and members costs one search
+// per region instead of one per type.
+type typeIndex struct {
+	once  sync.Once
+	index map[string][]*node // keyed by lower-case type, in region order
+	err   error
+}
+
+func (s *Service) typeIndex(ctx context.Context) (map[string][]*node, error) {
+	idx := loadersFrom(ctx).types
+	idx.once.Do(func() {
+		all, err := search(ctx, s.regions, repository.SearchFilter{})
+		if err != nil {
+			idx.err = err
+			return
+		}
+		idx.index = map[string][]*node{}
+		for _, n := range all {
+			seen := map[string]bool{}
+			for _, t := range n.Types() {
+				key := strings.ToLower(t)
+				if !seen[key] {
+					seen[key] = true
+					idx.index[key] = append(idx.index[key], n)
+				}
+			}
+		}
+	})
+	return idx.index, idx.err
+}
+
+// typeMembers returns the Pokemon of t's regions with the type in either slot.
+func (s *Service) typeMembers(ctx context.Context, t typeRef) ([]*node, error) {
+	index, err := s.typeIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members := index[strings.ToLower(strings.TrimSpace(t.name))]
+	if len(t.regions) == len(s.regions) {
+		return members, nil
+	}
+	in := make(map[string]bool, len(t.regions))
+	for _, r := range t.regions {
+		in[r.Name] = true
+	}
+	out := []*node{}
+	for _, n := range members {
+		if in[n.Region] {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package grpcapi
+
+import (
+	pokedexv1 "GO-Mongo/gen/pokedex/v1"
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"strings"
+)
+
+// ToProto converts the stored document to its protobuf message.
+func ToProto(p models.Pokemon) *pokedexv1.Pokemon {
+	stats := p.BaseStats()
+	return &pokedexv1.Pokemon{
+		Id:            int32(p.Number()),
+		DexNumber:     p.DexNumber,
+		Name:          p.Name,
+		Types:         p.Types(),
+		Abilities:     p.Abilities(),
+		HiddenAbility: strings.TrimSpace(p.HiddenAbility),
+		EggGroups:     p.EggGroups(),
+		IsLegendary:   p.Legendary(),
+		Bio:           p.Bio,
+		BaseStats: &pokedexv1.BaseStats{
+			Hp:        int32(stats.HP),
+			Attack:    int32(stats.Attack),
+			Defense:   int32(stats.Defense),
+			SpAttack:  int32(stats.SpAttack),
+			SpDefense: int32(stats.SpDefense),
+			Speed:     int32(stats.Speed),
+			Total:     int32(stats.Total),
+		},
+	}
+}
+
+// ToProtoList converts a slice of documents.
+func ToProtoList(ps []models.Pokemon) []*pokedexv1.Pokemon {
+	out := make([]*pokedexv1.Pokemon, len(ps))
+	for i, p := range ps {
+		out[i] = ToProto(p)
+	}
+	return out
+}
+
+// StatsToProto converts the dataset summary.
+func StatsToProto(s repository.StatsSummary) *pokedexv1.GetStatsResponse {
+	dist := make(map[string]int64, len(s.TypeDistribution))
+	for t, n := range s.TypeDistribution {
+		dist[t] = int64(n)
+	}
+	return &pokedexv1.GetStatsResponse{
+		TotalPokemon:     s.TotalPokemon,
+		LegendaryCount:   s.LegendaryCount,
+		TypeDistribution: dist,
+	}
+}
@@ -0,0 +1,114 @@
+package grpcapi
+
+import (
+	"GO-Mongo/logger"
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata key carrying the request ID, the gRPC
+// counterpart of the X-Request-ID header.
+const requestIDKey = "x-request-id"
+
+// withRequest tags ctx with a request-scoped logger and sends the request ID
+// back in the response header.
+func withRequest(ctx context.Context, base *slog.Logger, method string) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDKey); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	id = logger.EnsureRequestID(id)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	return logger.WithContext(ctx, base.With(slog.String("request_id", id), slog.String("grpc_method", method)))
+}
+
+func unaryInterceptor(base *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx = withRequest(ctx, base, info.FullMethod)
+		start := time.Now()
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = recovered(ctx, rec)
+			}
+			accessLog(ctx, start, err)
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func streamInterceptor(base *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := withRequest(ss.Context(), base, info.FullMethod)
+		start := time.Now()
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = recovered(ctx, rec)
+			}
+			accessLog(ctx, start, err)
+		}()
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream replaces the stream's context with the tagged one.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+func accessLog(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.NotFound, codes.InvalidArgument, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	logger.FromContext(ctx).LogAttrs(ctx, level, "rpc",
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	)
+}
+
+func recovered(ctx context.Context, rec any) error {
+	logger.FromContext(ctx).Error("panic recovered",
+		slog.Any("panic", rec),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return status.Error(codes.Internal, "an internal error occurred")
+}
+
+// toStatus maps repository error kinds onto gRPC codes. Unexpected errors are
+// logged and reported without their cause.
+func toStatus(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "pokemon not found")
+	case errors.Is(err, repository.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	case errors.Is(err, repository.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "the data store did not respond in time")
+	case errors.Is(err, repository.ErrUnavailable):
+		return status.Error(codes.Unavailable, "the data store is unavailable")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	default:
+		logger.FromContext(ctx).Error("rpc failed", "error", err)
+		return status.Error(codes.Internal, "an internal error occurred")
+	}
+}
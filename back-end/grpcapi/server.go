@@ -0,0 +1,128 @@
+// Package grpcapi serves the Pokedex over gRPC. It shares the repository
+// layer with the REST handlers, so both transports return the same data and
+// benefit from the same cache.
+package grpcapi
+
+import (
+	pokedexv1 "GO-Mongo/gen/pokedex/v1"
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Options carries the dependencies of New.
+type Options struct {
+	Logger  *slog.Logger
+	Pokemon repository.PokemonRepository
+}
+
+// New returns a gRPC server with PokedexService, the standard health service
+// and server reflection registered. The health server reports SERVING for
+// the whole server and for PokedexService; set it to NOT_SERVING before
+// shutting down.
+func New(opts Options) (*grpc.Server, *health.Server) {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor(opts.Logger)),
+		grpc.ChainStreamInterceptor(streamInterceptor(opts.Logger)),
+	)
+	pokedexv1.RegisterPokedexServiceServer(srv, &service{repo: opts.Pokemon})
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthSrv.SetServingStatus(pokedexv1.PokedexService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	reflection.Register(srv)
+	return srv, healthSrv
+}
+
+type service struct {
+	pokedexv1.UnimplementedPokedexServiceServer
+	repo repository.PokemonRepository
+}
+
+func (s *service) Get(ctx context.Context, req *pokedexv1.GetRequest) (*pokedexv1.GetResponse, error) {
+	switch lookup := req.GetLookup().(type) {
+	case *pokedexv1.GetRequest_Id:
+		if lookup.Id < 1 {
+			return nil, status.Error(codes.InvalidArgument, "id must be a positive dex number")
+		}
+		p, err := s.repo.GetByDexNumber(ctx, int(lookup.Id))
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		return &pokedexv1.GetResponse{Pokemon: ToProto(p)}, nil
+	case *pokedexv1.GetRequest_Name:
+		if strings.TrimSpace(lookup.Name) == "" {
+			return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+		}
+		p, err := s.repo.GetByName(ctx, lookup.Name)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		return &pokedexv1.GetResponse{Pokemon: ToProto(p)}, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either id or name is required")
+	}
+}
+
+func (s *service) List(req *pokedexv1.ListRequest, stream grpc.ServerStreamingServer[pokedexv1.ListResponse]) error {
+	ctx := stream.Context()
+	list := s.repo.List
+	if req.GetLegendaryOnly() {
+		list = s.repo.Legendary
+	}
+	pokemons, err := list(ctx)
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	// The repository does not promise an order, and its slice may be shared
+	// with the cache, so sort a copy.
+	pokemons = slices.Clone(pokemons)
+	slices.SortStableFunc(pokemons, func(a, b models.Pokemon) int { return a.Number() - b.Number() })
+	for _, p := range pokemons {
+		if err := stream.Send(&pokedexv1.ListResponse{Pokemon: ToProto(p)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) Search(ctx context.Context, req *pokedexv1.SearchRequest) (*pokedexv1.SearchResponse, error) {
+	filter := repository.SearchFilter{Query: req.GetQuery(), Type: req.GetType()}
+	if req.Legendary != nil {
+		b := req.GetLegendary()
+		filter.Legendary = &b
+	}
+	pokemons, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pokedexv1.SearchResponse{Pokemon: ToProtoList(pokemons)}, nil
+}
+
+func (s *service) GetTypes(ctx context.Context, _ *pokedexv1.GetTypesRequest) (*pokedexv1.GetTypesResponse, error) {
+	types, err := s.repo.Types(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pokedexv1.GetTypesResponse{Types: types}, nil
+}
+
+func (s *service) GetStats(ctx context.Context, _ *pokedexv1.GetStatsRequest) (*pokedexv1.GetStatsResponse, error) {
+	summary, err := s.repo.Stats(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return StatsToProto(summary), nil
+}
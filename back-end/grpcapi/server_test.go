@@ -0,0 +1,47 @@
+package grpcapi
+
+import (
+	pokedexv1 "GO-Mongo/gen/pokedex/v1"
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/repository"
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// listStream collects what List sends.
+type listStream struct {
+	grpc.ServerStream
+	sent []*pokedexv1.ListResponse
+}
+
+func (s *listStream) Context() context.Context { return context.Background() }
+
+func (s *listStream) Send(r *pokedexv1.ListResponse) error {
+	s.sent = append(s.sent, r)
+	return nil
+}
+
+func TestListStreamsInDexOrder(t *testing.T) {
+	pokemons := fixture.Dataset(t, "kanto")
+	slices.Reverse(pokemons)
+	svc := &service{repo: repository.NewMemory(pokemons)}
+
+	stream := &listStream{}
+	if err := svc.List(&pokedexv1.ListRequest{}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != len(pokemons) {
+		t.Fatalf("sent %d Pokemon, want %d", len(stream.sent), len(pokemons))
+	}
+	for i, r := range stream.sent {
+		if got := r.GetPokemon().GetId(); got != int32(i+1) {
+			t.Fatalf("message %d is #%d, want #%d", i, got, i+1)
+		}
+	}
+	if pokemons[0].Number() != 151 {
+		t.Errorf("List reordered the repository's slice")
+	}
+}
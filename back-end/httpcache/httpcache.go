@@ -0,0 +1,191 @@
+// Package httpcache adds ETag, Last-Modified and Cache-Control headers to GET
+// responses and answers conditional requests with 304 Not Modified.
+package httpcache
+
+import (
+	"GO-Mongo/logger"
+	"GO-Mongo/repository"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionSource reports the current dataset version.
+type VersionSource interface {
+	Version(ctx context.Context) (repository.DatasetVersion, error)
+}
+
+// Policy selects the Cache-Control value sent with successful responses.
+type Policy struct {
+	Default string            // used for routes missing from Routes
+	Routes  map[string]string // keyed by Gin route pattern, e.g. /api/pokemon/:id
+	// Revision is mixed into version ETags. Changing it invalidates them when
+	// a release changes response bodies while the dataset stays the same.
+	Revision string
+	// Dynamic lists the routes whose body changes while the dataset does
+	// not, such as random picks. They always get ETags hashed from the body.
+	Dynamic map[string]bool
+}
+
+func (p Policy) cacheControl(route string) string {
+	if v, ok := p.Routes[route]; ok {
+		return v
+	}
+	return p.Default
+}
+
+// Middleware makes GET responses cacheable. When the dataset version is known
+// and the route is not Dynamic, the ETag is derived from the version, the URL
+// and the Accept header, so a matching If-None-Match is answered before the
+// handler runs. Otherwise the ETag is a hash of the response body. Only 200
+// responses receive validators.
+func Middleware(versions VersionSource, policy Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		v, err := versions.Version(c.Request.Context())
+		if err != nil {
+			// Serve the request anyway, just without validators.
+			logger.FromContext(c.Request.Context()).Warn("dataset version unavailable for ETag", "error", err)
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept")
+		var etag string
+		if v.Version > 0 && !policy.Dynamic[c.FullPath()] {
+			etag = versionETag(v, policy.Revision, c.Request)
+			if notModified(c.Request, etag, v.UpdatedAt) {
+				writeValidators(c, etag, v.UpdatedAt, policy.cacheControl(c.FullPath()))
+				c.AbortWithStatus(http.StatusNotModified)
+				return
+			}
+		}
+
+		original := c.Writer
+		w := &bufferedWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.status != http.StatusOK {
+			w.flush()
+			return
+		}
+		if etag == "" {
+			etag = bodyETag(w.buf.Bytes())
+			if notModified(c.Request, etag, time.Time{}) {
+				writeValidators(c, etag, time.Time{}, policy.cacheControl(c.FullPath()))
+				c.Writer.Header().Del("Content-Type")
+				c.AbortWithStatus(http.StatusNotModified)
+				return
+			}
+		}
+		writeValidators(c, etag, v.UpdatedAt, policy.cacheControl(c.FullPath()))
+		w.flush()
+	}
+}
+
+func writeValidators(c *gin.Context, etag string, modified time.Time, cacheControl string) {
+	c.Header("ETag", etag)
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	// A Cache-Control set by the handler wins over the policy.
+	if cacheControl != "" && c.Writer.Header().Get("Cache-Control") == "" {
+		c.Header("Cache-Control", cacheControl)
+	}
+}
+
+// notModified implements the If-None-Match / If-Modified-Since precedence
+// of RFC 9110 section 13.2.2.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !modified.IsZero() {
+		t, err := http.ParseTime(ims)
+		return err == nil && !modified.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
+// etagMatches uses the weak comparison required for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func versionETag(v repository.DatasetVersion, revision string, r *http.Request) string {
+	sum := sha256.Sum256([]byte(strconv.FormatInt(v.Version, 10) + "\x00" + revision + "\x00" + r.URL.RequestURI() + "\x00" + r.Header.Get("Accept")))
+	return `"v` + strconv.FormatInt(v.Version, 10) + "-" + hex.EncodeToString(sum[:12]) + `"`
+}
+
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// bufferedWriter holds the handler's response so validators can be added, or
+// the body replaced by a 304, once the status and body are known.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.buf.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	return w.buf.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int { return w.buf.Len() }
+
+func (w *bufferedWriter) Written() bool { return w.status != 0 }
+
+func (w *bufferedWriter) flush() {
+	if w.status == 0 {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+}
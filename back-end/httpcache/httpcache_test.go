@@ -0,0 +1,165 @@
+package httpcache
+
+import (
+	"GO-Mongo/repository"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fixedVersion struct {
+	v   repository.DatasetVersion
+	err error
+}
+
+func (f fixedVersion) Version(context.Context) (repository.DatasetVersion, error) {
+	return f.v, f.err
+}
+
+var modified = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// newEngine serves /static and the Dynamic route /random behind Middleware
+// and counts handler runs.
+func newEngine(versions VersionSource, runs *int, body *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware(versions, Policy{
+		Default: "public, max-age=60",
+		Routes:  map[string]string{"/random": "no-cache"},
+		Dynamic: map[string]bool{"/random": true},
+	}))
+	handler := func(c *gin.Context) {
+		*runs++
+		c.String(http.StatusOK, *body)
+	}
+	r.GET("/static", handler)
+	r.GET("/random", handler)
+	r.GET("/missing", func(c *gin.Context) { c.String(http.StatusNotFound, "not found") })
+	return r
+}
+
+func get(r http.Handler, target string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestVersionETag(t *testing.T) {
+	runs, body := 0, "bulbasaur"
+	r := newEngine(fixedVersion{v: repository.DatasetVersion{Version: 3, UpdatedAt: modified}}, &runs, &body)
+
+	first := get(r, "/static", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Last-Modified") != modified.Format(http.TimeFormat) {
+		t.Fatalf("first response: %d, ETag %q, Last-Modified %q", first.Code, etag, first.Header().Get("Last-Modified"))
+	}
+	if cc := first.Header().Get("Cache-Control"); cc != "public, max-age=60" {
+		t.Errorf("Cache-Control = %q, want the default policy", cc)
+	}
+	if other := get(r, "/static", map[string]string{"Accept": "application/x-protobuf"}).Header().Get("ETag"); other == etag {
+		t.Error("ETag does not vary with Accept")
+	}
+
+	past, future := modified.Add(-time.Hour).Format(http.TimeFormat), modified.Add(time.Hour).Format(http.TimeFormat)
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"matching ETag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"weak ETag in a list", map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified},
+		{"any ETag", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"other ETag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": future}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": past}, http.StatusOK},
+		{"unparsable date", map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+		// If-None-Match wins over If-Modified-Since, whichever way they disagree.
+		{"ETag mismatch beats an old date", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": future}, http.StatusOK},
+		{"ETag match beats a new date", map[string]string{"If-None-Match": etag, "If-Modified-Since": past}, http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs = 0
+			w := get(r, "/static", tt.header)
+			if w.Code != tt.want {
+				t.Fatalf("status %d, want %d", w.Code, tt.want)
+			}
+			if w.Code != http.StatusNotModified {
+				return
+			}
+			if runs != 0 {
+				t.Error("the handler ran, want the 304 answered before it")
+			}
+			if w.Body.Len() != 0 || w.Header().Get("ETag") != etag || w.Header().Get("Cache-Control") == "" {
+				t.Errorf("304 with body %q, ETag %q, Cache-Control %q", w.Body, w.Header().Get("ETag"), w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestBodyETag(t *testing.T) {
+	cases := []struct {
+		name     string
+		versions VersionSource
+		target   string
+	}{
+		{"unrecorded version", fixedVersion{}, "/static"},
+		{"dynamic route", fixedVersion{v: repository.DatasetVersion{Version: 3, UpdatedAt: modified}}, "/random"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs, body := 0, "bulbasaur"
+			r := newEngine(tc.versions, &runs, &body)
+
+			first := get(r, tc.target, nil)
+			etag := first.Header().Get("ETag")
+			if first.Code != http.StatusOK || etag != bodyETag([]byte("bulbasaur")) {
+				t.Fatalf("status %d, ETag %q, want 200 with the body's hash", first.Code, etag)
+			}
+
+			w := get(r, tc.target, map[string]string{"If-None-Match": etag})
+			if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+				t.Errorf("same body: status %d, body %q, Content-Type %q, want an empty 304", w.Code, w.Body, w.Header().Get("Content-Type"))
+			}
+			if runs != 2 {
+				t.Errorf("handler ran %d times, want 2: a body ETag needs the body", runs)
+			}
+
+			body = "ivysaur"
+			if w := get(r, tc.target, map[string]string{"If-None-Match": etag}); w.Code != http.StatusOK || w.Body.String() != "ivysaur" {
+				t.Errorf("changed body: status %d, body %q, want 200 with the new body", w.Code, w.Body)
+			}
+		})
+	}
+
+	t.Run("dynamic route ignores dates", func(t *testing.T) {
+		runs, body := 0, "bulbasaur"
+		r := newEngine(fixedVersion{v: repository.DatasetVersion{Version: 3, UpdatedAt: modified}}, &runs, &body)
+		w := get(r, "/random", map[string]string{"If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat)})
+		if w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "no-cache" {
+			t.Errorf("status %d, Cache-Control %q, want 200 with the route's policy", w.Code, w.Header().Get("Cache-Control"))
+		}
+	})
+}
+
+func TestNoValidators(t *testing.T) {
+	runs, body := 0, "bulbasaur"
+	r := newEngine(fixedVersion{v: repository.DatasetVersion{Version: 3, UpdatedAt: modified}}, &runs, &body)
+	if w := get(r, "/missing", nil); w.Code != http.StatusNotFound || w.Header().Get("ETag") != "" || w.Body.String() != "not found" {
+		t.Errorf("404: ETag %q, body %q, want the response unchanged", w.Header().Get("ETag"), w.Body)
+	}
+
+	r = newEngine(fixedVersion{err: errors.New("mongo down")}, &runs, &body)
+	if w := get(r, "/static", map[string]string{"If-None-Match": "*"}); w.Code != http.StatusOK || w.Header().Get("ETag") != "" {
+		t.Errorf("without a version: status %d, ETag %q, want 200 without validators", w.Code, w.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,61 @@
+// Package fixture loads the datasets shipped in jsonImport for tests.
+package fixture
+
+import (
+	"GO-Mongo/models"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Dataset returns the Pokemon of region ("kanto" or "johto") as imported
+// into MongoDB, failing t if the file cannot be read.
+func Dataset(t testing.TB, region string) []models.Pokemon {
+	t.Helper()
+	var pokemons []models.Pokemon
+	decode(t, Path(region), &pokemons)
+	return pokemons
+}
+
+// Moves returns the moves and learnsets imported into MongoDB.
+func Moves(t testing.TB) ([]models.Move, []models.Learnset) {
+	t.Helper()
+	var moves []models.Move
+	var learnsets []models.Learnset
+	decode(t, filepath.Join(jsonImportDir(), "moves", "moves.json"), &moves)
+	decode(t, filepath.Join(jsonImportDir(), "moves", "learnsets.json"), &learnsets)
+	return moves, learnsets
+}
+
+// Evolutions returns the evolutions imported into MongoDB.
+func Evolutions(t testing.TB) []models.Evolution {
+	t.Helper()
+	var evolutions []models.Evolution
+	decode(t, filepath.Join(jsonImportDir(), "evolutions", "evolutions.json"), &evolutions)
+	return evolutions
+}
+
+// Path returns the path of the dataset file of region, wherever the test
+// runs from.
+func Path(region string) string {
+	return filepath.Join(jsonImportDir(), region, "pokemon_"+region+"_dataset.json")
+}
+
+// jsonImportDir is the jsonImport directory next to this package's parent.
+func jsonImportDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "jsonImport")
+}
+
+func decode(t testing.TB, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", filepath.Base(path), err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decoding %s: %v", filepath.Base(path), err)
+	}
+}
@@ -3,49 +3,364 @@ package main
 import (
 	"GO-Mongo/config"
 	"GO-Mongo/db"
+	"GO-Mongo/logger"
+	"GO-Mongo/models"
+	"GO-Mongo/repository"
 	"context"       // นำเข้า context สำหรับจัดการ timeout/cancel ของ process
 	"encoding/json" // นำเข้า json สำหรับการจัดการข้อมูล JSON
-	"fmt"           // นำเข้า fmt สำหรับแสดงผลข้อความ
+	"errors"        // นำเข้า errors สำหรับรวม error หลายรายการ
+	"fmt"           // นำเข้า fmt สำหรับสร้างข้อความ error
 	"io"            // นำเข้า io สำหรับการอ่านไฟล์
-	"log"           // นำเข้า log สำหรับแสดง log ข้อผิดพลาด
+	"log"           // นำเข้า log สำหรับแสดง log ข้อผิดพลาดก่อนที่ logger จะพร้อม
+	"log/slog"      // นำเข้า slog สำหรับเขียน structured log
+	"maps"          // นำเข้า maps สำหรับเรียง dex number ที่ต้องแก้
 	"os"            // นำเข้า os สำหรับการจัดการไฟล์และระบบปฏิบัติการ
+	"slices"        // นำเข้า slices สำหรับเรียง dex number ที่ต้องแก้
+	"strings"       // นำเข้า strings สำหรับเทียบชื่อ ability
 
+	"go.mongodb.org/mongo-driver/bson"  // นำเข้า bson สำหรับสร้าง filter และ update
 	"go.mongodb.org/mongo-driver/mongo" // นำเข้า mongo driver สำหรับเชื่อมต่อ MongoDB
 )
 
 // ประกาศ package ชื่อ main
 
+// ไฟล์ข้อมูลที่นำเข้า
+const (
+	kantoFile     = "jsonImport/kanto/pokemon_kanto_dataset.json"
+	johtoFile     = "jsonImport/johto/pokemon_johto_dataset.json"
+	abilitiesFile = "jsonImport/abilities/abilities.json"
+	movesFile     = "jsonImport/moves/moves.json"
+	learnsetsFile = "jsonImport/moves/learnsets.json"
+)
+
 func main() {
 	cfg, err := config.LoadConfig("env.yaml") // โหลดการตั้งค่าจากไฟล์ env.yaml
 	if err != nil {
 		log.Fatal("Failed to load configuration:", err) // ถ้าโหลดการตั้งค่าไม่สำเร็จ ให้แสดง log และหยุดโปรแกรม
 	}
-	db.Connect(cfg) // เชื่อมต่อกับ MongoDB ด้วยการตั้งค่าที่โหลดมา
+	appLogger, err := logger.New(cfg.Logging) // สร้าง logger ตามการตั้งค่าใน env.yaml
 	if err != nil {
-		log.Fatal("Failed to connect to MongoDB:", err) // ถ้าเชื่อมต่อ MongoDB ไม่สำเร็จ ให้แสดง log และหยุดโปรแกรม
-	} else {
-		fmt.Println("Connected to MongoDB successfully") // แสดงข้อความเมื่อเชื่อมต่อ MongoDB สำเร็จ
+		log.Fatal("Failed to create logger:", err)
+	}
+	if err := db.Connect(cfg, appLogger); err != nil { // เชื่อมต่อกับ MongoDB ด้วยการตั้งค่าที่โหลดมา
+		fatal(appLogger, "failed to connect to MongoDB", err) // ถ้าเชื่อมต่อ MongoDB ไม่สำเร็จ ให้แสดง log และหยุดโปรแกรม
 	}
 	ctx := context.Background() // สร้าง context สำหรับการเรียกใช้งาน
 
+	// ตรวจสอบว่าทุก ability ใน dataset มีอยู่ใน abilities.json ก่อน import อะไรเลย
+	abilities, err := LoadAbilities(abilitiesFile)
+	if err != nil {
+		fatal(appLogger, "invalid abilities file", err)
+	}
+	for _, path := range []string{kantoFile, johtoFile} {
+		if err := CheckAbilities(path, abilities); err != nil {
+			fatal(appLogger, "dataset references unknown abilities", err)
+		}
+	}
+
+	// ตรวจสอบว่าทุกท่าใน learnsets.json มีอยู่ใน moves.json และทุก dex number มีอยู่ใน dataset
+	moves, err := LoadMoves(movesFile)
+	if err != nil {
+		fatal(appLogger, "invalid moves file", err)
+	}
+	if err := CheckLearnsets(learnsetsFile, moves, kantoFile, johtoFile); err != nil {
+		fatal(appLogger, "invalid learnsets file", err)
+	}
+
+	// Import abilities (shared by every region) to abilities collection
+	abilityCollection := db.Collection.Database().Collection("abilities")
+	if err := ImportJSONToMongo(ctx, appLogger, abilityCollection, abilitiesFile); err != nil {
+		fatal(appLogger, "abilities import failed", err)
+	}
+
 	// Import Kanto dataset to kanto_pokemons collection
-	fmt.Println("🚀 Starting Kanto Pokemon import...")
 	kantoCollection := db.Collection.Database().Collection("kanto_pokemons")
-	ImportJSONToMongo(ctx, kantoCollection, "jsonImport/kanto/pokemon_kanto_dataset.json")
+	if err := ImportJSONToMongo(ctx, appLogger, kantoCollection, kantoFile); err != nil {
+		fatal(appLogger, "kanto import failed", err)
+	}
 
 	// Import Johto dataset to johto_pokemons collection
-	fmt.Println("🚀 Starting Johto Pokemon import...")
 	johtoCollection := db.Collection.Database().Collection("johto_pokemons")
-	ImportJSONToMongo(ctx, johtoCollection, "jsonImport/johto/pokemon_johto_dataset.json")
+	if err := ImportJSONToMongo(ctx, appLogger, johtoCollection, johtoFile); err != nil {
+		fatal(appLogger, "johto import failed", err)
+	}
+
+	// collection ที่ import ไว้ก่อนจะถูกข้าม จึงต้องแก้ชื่อ ability ที่เก็บไว้ (เช่น "Keen" -> "Keen Eye") ให้ตรงกับ dataset
+	for _, r := range []struct {
+		collection *mongo.Collection
+		file       string
+	}{{kantoCollection, kantoFile}, {johtoCollection, johtoFile}} {
+		if err := SyncAbilities(ctx, appLogger, r.collection, r.file, abilities); err != nil {
+			fatal(appLogger, "stored abilities do not match the dataset", err)
+		}
+	}
+
+	// Import evolutions (shared by every region) to evolutions collection
+	evolutionCollection := db.Collection.Database().Collection("evolutions")
+	if err := ImportEvolutions(ctx, appLogger, evolutionCollection, "jsonImport/evolutions/evolutions.json"); err != nil {
+		fatal(appLogger, "evolutions import failed", err)
+	}
+
+	// Import moves and learnsets (shared by every region)
+	moveCollection := db.Collection.Database().Collection("moves")
+	if err := ImportJSONToMongo(ctx, appLogger, moveCollection, movesFile); err != nil {
+		fatal(appLogger, "moves import failed", err)
+	}
+	learnsetCollection := db.Collection.Database().Collection("learnsets")
+	if err := ImportJSONToMongo(ctx, appLogger, learnsetCollection, learnsetsFile); err != nil {
+		fatal(appLogger, "learnsets import failed", err)
+	}
+}
+
+func fatal(l *slog.Logger, msg string, err error) {
+	l.Error(msg, "error", err)
+	os.Exit(1)
 }
 
-func ImportJSONToMongo(ctx context.Context, collection *mongo.Collection, jsonFilePath string) { // ฟังก์ชันสำหรับนำเข้าข้อมูล JSON documents ไปยัง collection ที่กำหนด
+// LoadAbilities อ่านและตรวจสอบ abilities.json แล้วคืนชื่อ ability ทั้งหมด (ตัวพิมพ์เล็ก)
+func LoadAbilities(path string) (map[string]bool, error) {
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JSON file: %w", err)
+	}
+	var abilities []models.Ability
+	if err := json.Unmarshal(byteValue, &abilities); err != nil {
+		return nil, fmt.Errorf("unmarshalling JSON data: %w", err)
+	}
+	names := make(map[string]bool, len(abilities))
+	for i, a := range abilities {
+		if err := a.Validate(); err != nil {
+			return nil, fmt.Errorf("ability %d: %w", i, err)
+		}
+		key := strings.ToLower(strings.TrimSpace(a.Name))
+		if names[key] {
+			return nil, fmt.Errorf("ability %d: %s is listed twice", i, a.Name)
+		}
+		names[key] = true
+	}
+	return names, nil
+}
+
+// CheckAbilities คืน error ที่รวมทุก ability ใน dataset ที่ไม่มีใน abilities (เช่นชื่อที่สะกดผิดหรือถูกตัด)
+func CheckAbilities(path string, abilities map[string]bool) error {
+	pokemons, err := readPokemons(path)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, p := range pokemons {
+		fields := []struct{ field, value string }{
+			{"ability_01", p.Ability01},
+			{"ability_02", p.Ability02},
+			{"hidden_ability", p.HiddenAbility},
+		}
+		for _, f := range fields {
+			name := strings.TrimSpace(f.value)
+			if name != "" && !abilities[strings.ToLower(name)] {
+				errs = append(errs, fmt.Errorf("%s %s: %s %q is not in %s", p.DexNumber, p.Name, f.field, name, abilitiesFile))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", path, errors.Join(errs...))
+	}
+	return nil
+}
+
+// SyncAbilities แก้ ability_01, ability_02 และ hidden_ability ที่เก็บใน collection ให้ตรงกับ dataset
+// แล้วเพิ่ม dataset version ถ้ามีการแก้ คืน error ถ้ามี document ที่ยังอ้างถึง ability ที่ไม่มีใน abilities
+func SyncAbilities(ctx context.Context, l *slog.Logger, collection *mongo.Collection, jsonFilePath string, abilities map[string]bool) error {
+	l = l.With("collection", collection.Name(), "file", jsonFilePath)
+	dataset, err := readPokemons(jsonFilePath)
+	if err != nil {
+		return err
+	}
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("finding documents: %w", err)
+	}
+	var stored []models.Pokemon
+	if err := cursor.All(ctx, &stored); err != nil {
+		return fmt.Errorf("decoding documents: %w", err)
+	}
+
+	fixes, err := abilityFixes(stored, dataset, abilities)
+	if err != nil {
+		return fmt.Errorf("%s: %w", collection.Name(), err)
+	}
+	if len(fixes) == 0 {
+		l.Info("stored abilities match the dataset")
+		return nil
+	}
+	for _, dex := range slices.Sorted(maps.Keys(fixes)) {
+		if _, err := collection.UpdateMany(ctx, bson.M{"dex_number": dex}, bson.M{"$set": fixes[dex]}); err != nil {
+			return fmt.Errorf("updating %s: %w", dex, err)
+		}
+	}
+	l.Info("fixed stored abilities", "documents", len(fixes))
+
+	version, err := repository.BumpVersion(ctx, collection.Database()) // เพิ่ม dataset version เพื่อให้ API ล้าง cache
+	if err != nil {
+		return fmt.Errorf("bumping dataset version: %w", err)
+	}
+	l.Info("dataset version bumped", "version", version.Version)
+	return nil
+}
+
+// abilityFixes เทียบ document ที่เก็บไว้กับ dataset ตาม dex number แล้วคืน $set ของ document ที่ ability ไม่ตรง
+// document ที่ไม่มีใน dataset ต้องอ้างถึง ability ที่มีใน abilities เท่านั้น
+func abilityFixes(stored, dataset []models.Pokemon, abilities map[string]bool) (map[string]bson.M, error) {
+	byDex := make(map[string]models.Pokemon, len(dataset))
+	for _, p := range dataset {
+		byDex[p.DexNumber] = p
+	}
+	fixes := map[string]bson.M{}
+	var errs []error
+	for _, p := range stored {
+		want, ok := byDex[p.DexNumber]
+		fields := []struct{ field, stored, want string }{
+			{"ability_01", p.Ability01, want.Ability01},
+			{"ability_02", p.Ability02, want.Ability02},
+			{"hidden_ability", p.HiddenAbility, want.HiddenAbility},
+		}
+		if !ok {
+			for _, f := range fields {
+				name := strings.TrimSpace(f.stored)
+				if name != "" && !abilities[strings.ToLower(name)] {
+					errs = append(errs, fmt.Errorf("%s %s: stored %s %q is not in %s and the dex number is not in the dataset", p.DexNumber, p.Name, f.field, name, abilitiesFile))
+				}
+			}
+			continue
+		}
+		for _, f := range fields {
+			if f.stored != f.want {
+				if fixes[p.DexNumber] == nil {
+					fixes[p.DexNumber] = bson.M{}
+				}
+				fixes[p.DexNumber][f.field] = f.want
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return fixes, nil
+}
+
+// LoadMoves อ่านและตรวจสอบ moves.json แล้วคืนชื่อท่าทั้งหมด (ตัวพิมพ์เล็ก)
+func LoadMoves(path string) (map[string]bool, error) {
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JSON file: %w", err)
+	}
+	var moves []models.Move
+	if err := json.Unmarshal(byteValue, &moves); err != nil {
+		return nil, fmt.Errorf("unmarshalling JSON data: %w", err)
+	}
+	names := make(map[string]bool, len(moves))
+	for i, m := range moves {
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("move %d: %w", i, err)
+		}
+		key := strings.ToLower(strings.TrimSpace(m.Name))
+		if names[key] {
+			return nil, fmt.Errorf("move %d: %s is listed twice", i, m.Name)
+		}
+		names[key] = true
+	}
+	return names, nil
+}
+
+// CheckLearnsets ตรวจสอบทุก learnset: รูปแบบถูกต้อง, ท่ามีใน moves, dex number มีใน dataset และไม่ซ้ำต่อเวอร์ชัน
+func CheckLearnsets(path string, moves map[string]bool, datasets ...string) error {
+	dex := map[string]bool{}
+	for _, dataset := range datasets {
+		pokemons, err := readPokemons(dataset)
+		if err != nil {
+			return err
+		}
+		for _, p := range pokemons {
+			dex[p.DexNumber] = true
+		}
+	}
+
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading JSON file: %w", err)
+	}
+	var learnsets []models.Learnset
+	if err := json.Unmarshal(byteValue, &learnsets); err != nil {
+		return fmt.Errorf("unmarshalling JSON data: %w", err)
+	}
+	var errs []error
+	seen := map[[2]string]bool{}
+	for _, l := range learnsets {
+		if err := l.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !dex[l.DexNumber] {
+			errs = append(errs, fmt.Errorf("%s %s: dex number is not in any dataset", l.DexNumber, l.Version))
+		}
+		key := [2]string{l.DexNumber, strings.ToLower(l.Version)}
+		if seen[key] {
+			errs = append(errs, fmt.Errorf("%s %s: learnset is listed twice", l.DexNumber, l.Version))
+		}
+		seen[key] = true
+		for _, m := range l.Moves {
+			if !moves[strings.ToLower(strings.TrimSpace(m.Move))] {
+				errs = append(errs, fmt.Errorf("%s %s: move %q is not in %s", l.DexNumber, l.Version, m.Move, movesFile))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", path, errors.Join(errs...))
+	}
+	return nil
+}
+
+// readPokemons อ่าน dataset ของภูมิภาคหนึ่ง
+func readPokemons(path string) ([]models.Pokemon, error) {
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JSON file: %w", err)
+	}
+	var pokemons []models.Pokemon
+	if err := json.Unmarshal(byteValue, &pokemons); err != nil {
+		return nil, fmt.Errorf("unmarshalling JSON data: %w", err)
+	}
+	return pokemons, nil
+}
+
+// ImportEvolutions ตรวจสอบทุก document ในไฟล์ก่อน แล้วจึงนำเข้าด้วย ImportJSONToMongo
+// ไฟล์นี้สร้างโดย evolutionExtract และต้องผ่านการตรวจตาม REVIEW.md ที่ evolutionExtract สร้างก่อน
+func ImportEvolutions(ctx context.Context, l *slog.Logger, collection *mongo.Collection, jsonFilePath string) error {
+	byteValue, err := os.ReadFile(jsonFilePath) // อ่านไฟล์ evolutions ทั้งหมด
+	if err != nil {
+		return fmt.Errorf("reading JSON file: %w", err)
+	}
+	var evolutions []models.Evolution
+	if err := json.Unmarshal(byteValue, &evolutions); err != nil {
+		return fmt.Errorf("unmarshalling JSON data: %w", err)
+	}
+	for i, e := range evolutions {
+		if err := e.Validate(); err != nil { // ไม่นำเข้าเลยถ้ามี document ใดไม่ถูกต้อง
+			return fmt.Errorf("evolution %d: %w", i, err)
+		}
+	}
+	return ImportJSONToMongo(ctx, l, collection, jsonFilePath)
+}
+
+func ImportJSONToMongo(ctx context.Context, l *slog.Logger, collection *mongo.Collection, jsonFilePath string) error { // ฟังก์ชันสำหรับนำเข้าข้อมูล JSON documents ไปยัง collection ที่กำหนด
 	if collection == nil {
-		log.Fatal("Collection is nil - make sure to connect to MongoDB first")
-	} // ถ้าเกิด error ในการดึงชื่อ collection ให้แสดง log และหยุดโปรแกรม
+		return fmt.Errorf("collection is nil - make sure to connect to MongoDB first")
+	} // ถ้า collection ยังไม่ถูกสร้าง ให้คืนค่า error
+	l = l.With("collection", collection.Name(), "file", jsonFilePath)
+	l.Info("starting import")
+
 	collectionNames, err := collection.Database().ListCollectionNames(ctx, struct{}{}) // ดึงชื่อ collection ทั้งหมดใน database ที่กำหนด
 	if err != nil {
-		log.Fatal("Error listing collections:", err)
+		return fmt.Errorf("listing collections: %w", err)
 	}
 	found := false
 	for _, name := range collectionNames {
@@ -56,42 +371,48 @@ func ImportJSONToMongo(ctx context.Context, collection *mongo.Collection, jsonFi
 	} // ตรวจสอบว่า collection ที่ต้องการมีอยู่ใน database หรือไม่
 
 	if !found {
-		fmt.Printf("Collection '%s' not found, but proceeding with import (will be created)\n", collection.Name())
+		l.Info("collection not found, it will be created by the import")
 	} else {
 		// ตรวจสอบว่า collection มี document อยู่แล้วหรือไม่
 		count, err := collection.CountDocuments(ctx, struct{}{})
 		if err != nil {
-			log.Fatal("Error counting documents:", err)
+			return fmt.Errorf("counting documents: %w", err)
 		}
 
 		if count > 0 {
-			fmt.Printf("Collection '%s' already contains %d documents. Skipping import.\n", collection.Name(), count)
-			return // ออกจากฟังก์ชันไม่ต้อง import
+			l.Info("collection already populated, skipping import", "documents", count)
+			return nil // ออกจากฟังก์ชันไม่ต้อง import
 		}
-		fmt.Printf("Collection '%s' is empty. Proceeding with import.\n", collection.Name())
 	}
 
 	file, err := os.Open(jsonFilePath) // เปิดไฟล์ JSON ที่ต้องการนำเข้า
 	if err != nil {
-		log.Fatal("Error opening JSON file:", err) // ถ้าเกิด error ในการเปิดไฟล์ ให้แสดง log และหยุดโปรแกรม
+		return fmt.Errorf("opening JSON file: %w", err) // ถ้าเกิด error ในการเปิดไฟล์ ให้คืนค่า error
 	}
 	defer file.Close() // ปิดไฟล์เมื่อเสร็จสิ้น
 
 	byteValue, err := io.ReadAll(file) // อ่านเนื้อหาทั้งหมดจากไฟล์ JSON ที่เปิดไว้แล้ว และเก็บผลลัพธ์ในรูปแบบ []byte ไว้ในตัวแปร byteValue
 	if err != nil {
-		log.Fatal("Error reading JSON file:", err) // ถ้าเกิด error ในการอ่านไฟล์ ให้แสดง log และหยุดโปรแกรม
+		return fmt.Errorf("reading JSON file: %w", err) // ถ้าเกิด error ในการอ่านไฟล์ ให้คืนค่า error
 	}
 
 	var documents []interface{}                 // ประกาศตัวแปร documents เป็น slice ของ interface{} เพื่อเก็บข้อมูล JSON ที่จะนำเข้า
 	err = json.Unmarshal(byteValue, &documents) // แปลงข้อมูล JSON เป็น slice ของ interface{}
 	if err != nil {
-		log.Fatal("Error unmarshalling JSON data:", err) // ถ้าเกิด error ในการแปลงข้อมูล JSON ให้แสดง log และหยุดโปรแกรม
+		return fmt.Errorf("unmarshalling JSON data: %w", err) // ถ้าเกิด error ในการแปลงข้อมูล JSON ให้คืนค่า error
 	}
 
 	result, err := collection.InsertMany(ctx, documents) // แทรกข้อมูล JSON ลงใน collection ที่กำหนด
 	if err != nil {
-		log.Fatal("Error inserting documents into MongoDB:", err) // ถ้าเกิด error ในการแทรกข้อมูล ให้แสดง log และหยุดโปรแกรม
+		return fmt.Errorf("inserting documents into MongoDB: %w", err) // ถ้าเกิด error ในการแทรกข้อมูล ให้คืนค่า error
+	}
+	l.Info("import completed", "documents", len(result.InsertedIDs)) // แสดงจำนวนเอกสารที่นำเข้าสำเร็จ
+	l.Debug("inserted documents", "ids", result.InsertedIDs)         // แสดง ID ของเอกสารที่ถูกแทรก
+
+	version, err := repository.BumpVersion(ctx, collection.Database()) // เพิ่ม dataset version เพื่อให้ API ล้าง cache
+	if err != nil {
+		return fmt.Errorf("bumping dataset version: %w", err)
 	}
-	fmt.Printf("Successfully imported %d documents to %s collection\n", len(result.InsertedIDs), collection.Name()) // แสดงจำนวนเอกสารที่นำเข้าสำเร็จ
-	fmt.Println("Inserted documents:", result.InsertedIDs)                                                          // แสดง ID ของเอกสารที่ถูกแทรก
+	l.Info("dataset version bumped", "version", version.Version)
+	return nil
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"GO-Mongo/models"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCheckLearnsetsSeed(t *testing.T) {
+	// The paths of main are relative to back-end.
+	path := func(file string) string { return filepath.Join("..", file) }
+	moves, err := LoadMoves(path(movesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckLearnsets(path(learnsetsFile), moves, path(kantoFile), path(johtoFile)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckLearnsets(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	dataset := write("dataset.json", `[{"dex_number": "#0025", "name": "Pikachu"}, {"dex_number": "#0152", "name": "Chikorita"}]`)
+	moves := map[string]bool{"growl": true, "charm": true, "thunderbolt": true, "razor leaf": true}
+
+	tests := []struct {
+		name      string
+		learnsets string
+		want      []string // substrings of the error; none when valid
+	}{
+		{"every method", `[
+			{"dex_number": "#0025", "version": "crystal", "moves": [
+				{"move": "Growl", "method": "level-up", "level": 1},
+				{"move": "Charm", "method": "egg"},
+				{"move": "Thunderbolt", "method": "tutor"},
+				{"move": "thunderbolt", "method": "machine", "machine": "TM24"}]},
+			{"dex_number": "#0152", "version": "crystal", "moves": [{"move": "Razor Leaf", "method": "level-up", "level": 8}]}]`, nil},
+		{"unknown move and dex number", `[
+			{"dex_number": "#0026", "version": "crystal", "moves": [{"move": "Surf", "method": "tutor"}]}]`,
+			[]string{`#0026 crystal: dex number is not in any dataset`, `move "Surf" is not in`}},
+		{"listed twice", `[
+			{"dex_number": "#0025", "version": "crystal", "moves": []},
+			{"dex_number": "#0025", "version": "crystal", "moves": []}]`,
+			[]string{"learnset is listed twice"}},
+		{"unknown version group", `[{"dex_number": "#0025", "version": "crystal-clear", "moves": []}]`,
+			[]string{`unknown version group "crystal-clear"`}},
+		{"machine without its number", `[
+			{"dex_number": "#0025", "version": "crystal", "moves": [{"move": "Thunderbolt", "method": "machine"}]}]`,
+			[]string{"from a machine without its number"}},
+		{"unknown method", `[
+			{"dex_number": "#0025", "version": "crystal", "moves": [{"move": "Charm", "method": "event"}]}]`,
+			[]string{`unknown method "event"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckLearnsets(write("learnsets.json", tt.learnsets), moves, dataset)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("CheckLearnsets: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CheckLearnsets accepted the learnsets, want errors %q", tt.want)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("CheckLearnsets error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAbilityFixes(t *testing.T) {
+	abilities := map[string]bool{"keen eye": true, "shield dust": true, "run away": true, "tangled feet": true}
+	dataset := []models.Pokemon{
+		{DexNumber: "#0010", Name: "Caterpie", Ability01: "Shield Dust", HiddenAbility: "Run Away"},
+		{DexNumber: "#0016", Name: "Pidgey", Ability01: "Keen Eye", Ability02: "Tangled Feet"},
+		{DexNumber: "#0019", Name: "Rattata", Ability01: "Run Away"},
+	}
+	stored := []models.Pokemon{
+		{DexNumber: "#0010", Name: "Caterpie", Ability01: "Shield", HiddenAbility: "Run Away"},
+		{DexNumber: "#0016", Name: "Pidgey", Ability01: "Keen", Ability02: "Tangled Feet"},
+		{DexNumber: "#0019", Name: "Rattata", Ability01: "Run Away"},
+	}
+	got, err := abilityFixes(stored, dataset, abilities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bson.M{
+		"#0010": {"ability_01": "Shield Dust"},
+		"#0016": {"ability_01": "Keen Eye"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fixes = %v, want %v", got, want)
+	}
+
+	// A stored document the dataset no longer has cannot be fixed.
+	stored = append(stored, models.Pokemon{DexNumber: "#0021", Name: "Spearow", Ability01: "Keen"})
+	if _, err := abilityFixes(stored, dataset, abilities); err == nil || !strings.Contains(err.Error(), `#0021 Spearow: stored ability_01 "Keen"`) {
+		t.Errorf("err = %v, want one naming #0021's ability_01", err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package logger builds the service-wide slog logger and the Gin middleware
+// that tags every request with an X-Request-ID.
+package logger
+
+import (
+	"GO-Mongo/config"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type ctxKey struct{}
+
+// New returns a logger writing to stdout in the format and level from cfg.
+func New(cfg config.Logging) (*slog.Logger, error) {
+	return NewWithWriter(os.Stdout, cfg)
+}
+
+// NewWithWriter is New with an explicit destination.
+func NewWithWriter(w io.Writer, cfg config.Logging) (*slog.Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	case "text", "":
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("logger: unknown format %q (want json or text)", cfg.Format)
+	}
+	return slog.New(handler), nil
+}
+
+// ParseLevel converts a config level name into a slog.Level.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("logger: unknown level %q", s)
+	}
+	return level, nil
+}
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx, falling back to slog.Default.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader is read from incoming requests and echoed on responses.
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDKey    = "request_id"
+	maxRequestIDLen = 128
+)
+
+// RequestID accepts a well-formed X-Request-ID from the client or generates a
+// new one, echoes it on the response and stores a request-scoped logger
+// carrying it in the request context.
+func RequestID(base *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := EnsureRequestID(c.GetHeader(RequestIDHeader))
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+
+		l := base.With(slog.String("request_id", id))
+		c.Request = c.Request.WithContext(WithContext(c.Request.Context(), l))
+		c.Next()
+	}
+}
+
+// AccessLog replaces Gin's default logger with one structured line per request.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		FromContext(c.Request.Context()).LogAttrs(c.Request.Context(), level, "request",
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.String("query", c.Request.URL.RawQuery),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		)
+	}
+}
+
+// Recovery replaces gin.Recovery so that panics are logged through slog with
+// the request ID instead of being written to stderr. The panic is recorded on
+// the context as a 500 for the error middleware to render.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				FromContext(c.Request.Context()).Error("panic recovered",
+					slog.Any("panic", rec),
+					slog.String("stack", string(debug.Stack())),
+				)
+				_ = c.Error(fmt.Errorf("panic: %v", rec))
+				c.Status(http.StatusInternalServerError)
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID assigned by RequestID, or "" outside of it.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// EnsureRequestID returns id when it is usable as a request ID and a freshly
+// generated one otherwise. Transports other than Gin use it to apply the same
+// rules.
+func EnsureRequestID(id string) string {
+	if validRequestID(id) {
+		return id
+	}
+	return newRequestID()
+}
+
+// validRequestID rejects IDs that are empty, oversized or contain characters
+// that could forge log lines or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
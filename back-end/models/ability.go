@@ -0,0 +1,28 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ability struct สำหรับ MongoDB: หนึ่ง document ต่อหนึ่ง ability
+type Ability struct {
+	Name        string `json:"name" bson:"name"`               // ชื่อ ability ต้องตรงกับ ability_01/ability_02/hidden_ability ของ Pokemon
+	Description string `json:"description" bson:"description"` // คำอธิบายผลของ ability
+	Generation  int    `json:"generation" bson:"generation"`   // generation ที่ ability ปรากฏครั้งแรก
+}
+
+// Validate reports a document the API could not serve.
+func (a Ability) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(a.Description) == "" {
+		return fmt.Errorf("%s: description is required", a.Name)
+	}
+	if a.Generation < 1 {
+		return fmt.Errorf("%s: generation %d out of range", a.Name, a.Generation)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Evolution triggers.
+const (
+	TriggerLevel      = "level"      // leveling up, possibly with a condition
+	TriggerItem       = "item"       // using an item such as a Fire Stone
+	TriggerTrade      = "trade"      // trading, possibly while holding Item
+	TriggerFriendship = "friendship" // leveling up with high friendship
+	TriggerOther      = "other"      // anything else, described by Condition
+)
+
+// EvolutionMethod is how one Pokemon evolves into another.
+type EvolutionMethod struct {
+	Trigger   string `json:"trigger" bson:"trigger"`
+	Level     int    `json:"level,omitempty" bson:"level,omitempty"`         // minimum level for TriggerLevel
+	Item      string `json:"item,omitempty" bson:"item,omitempty"`           // item used or held
+	Condition string `json:"condition,omitempty" bson:"condition,omitempty"` // further requirements, e.g. "during the day"
+}
+
+// Evolution struct สำหรับ MongoDB: หนึ่ง document ต่อหนึ่งเส้นทางการ evolve
+type Evolution struct {
+	From            string `json:"from" bson:"from"` // ชื่อ Pokemon ก่อน evolve
+	To              string `json:"to" bson:"to"`     // ชื่อ Pokemon หลัง evolve
+	EvolutionMethod `bson:",inline"`
+}
+
+// Validate reports a document the API could not serve.
+func (e Evolution) Validate() error {
+	if strings.TrimSpace(e.From) == "" || strings.TrimSpace(e.To) == "" {
+		return errors.New("from and to are required")
+	}
+	if strings.EqualFold(strings.TrimSpace(e.From), strings.TrimSpace(e.To)) {
+		return fmt.Errorf("%s evolves into itself", e.From)
+	}
+	switch e.Trigger {
+	case TriggerLevel, TriggerTrade, TriggerFriendship, TriggerOther:
+	case TriggerItem:
+		if e.Item == "" {
+			return fmt.Errorf("%s -> %s: item trigger without an item", e.From, e.To)
+		}
+	default:
+		return fmt.Errorf("%s -> %s: unknown trigger %q", e.From, e.To, e.Trigger)
+	}
+	if e.Level < 0 || e.Level > 100 {
+		return fmt.Errorf("%s -> %s: level %d out of range", e.From, e.To, e.Level)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Move damage categories.
+const (
+	CategoryPhysical = "physical"
+	CategorySpecial  = "special"
+	CategoryStatus   = "status"
+)
+
+// LatestGeneration is the generation of the games Move values describe.
+const LatestGeneration = 9
+
+// splitGeneration is the first generation where each damaging move has its
+// own category; before it, the move's type decided.
+const splitGeneration = 4
+
+// specialTypes are the types of special moves before splitGeneration.
+var specialTypes = map[string]bool{
+	"Fire": true, "Water": true, "Grass": true, "Electric": true,
+	"Ice": true, "Psychic": true, "Dragon": true, "Dark": true,
+}
+
+// VersionGroups maps the game version groups that key learnsets to their
+// generation.
+var VersionGroups = map[string]int{
+	"red-blue": 1, "yellow": 1,
+	"gold-silver": 2, "crystal": 2,
+	"ruby-sapphire": 3, "emerald": 3, "firered-leafgreen": 3,
+	"diamond-pearl": 4, "platinum": 4, "heartgold-soulsilver": 4,
+	"black-white": 5, "black-2-white-2": 5,
+	"x-y": 6, "omega-ruby-alpha-sapphire": 6,
+	"sun-moon": 7, "ultra-sun-ultra-moon": 7, "lets-go-pikachu-lets-go-eevee": 7,
+	"sword-shield": 8, "brilliant-diamond-shining-pearl": 8, "legends-arceus": 8,
+	"scarlet-violet": 9,
+}
+
+// Move struct สำหรับ MongoDB: หนึ่ง document ต่อหนึ่งท่า (ค่าตามเกมล่าสุด และค่าในเกมก่อนหน้าที่ต่างออกไปใน Past)
+type Move struct {
+	Name     string       `json:"name" bson:"name"`                     // ชื่อท่า ต้องตรงกับ move ใน learnset
+	Type     string       `json:"type" bson:"type"`                     // ธาตุของท่า เช่น Fire
+	Category string       `json:"category" bson:"category"`             // physical, special หรือ status
+	Power    *int         `json:"power" bson:"power"`                   // null สำหรับท่า status และท่าที่ความเสียหายไม่ขึ้นกับ power เช่น Seismic Toss
+	Accuracy *int         `json:"accuracy" bson:"accuracy"`             // null สำหรับท่าที่ไม่มีวันพลาด
+	PP       int          `json:"pp" bson:"pp"`                         // จำนวนครั้งที่ใช้ได้
+	Effect   string       `json:"effect" bson:"effect"`                 // คำอธิบายผลของท่า
+	Past     []PastValues `json:"past,omitempty" bson:"past,omitempty"` // ค่าในเกมก่อนหน้า เรียงตาม generation
+}
+
+// PastValues are the values a move had up to and including Generation,
+// where they differ from the values of the next later entry, or from the
+// latest games for the last entry. Zero fields did not change.
+type PastValues struct {
+	Generation int    `json:"generation" bson:"generation"`
+	Type       string `json:"type,omitempty" bson:"type,omitempty"`
+	Power      *int   `json:"power,omitempty" bson:"power,omitempty"`
+	Accuracy   *int   `json:"accuracy,omitempty" bson:"accuracy,omitempty"`
+	PP         int    `json:"pp,omitempty" bson:"pp,omitempty"`
+	Effect     string `json:"effect,omitempty" bson:"effect,omitempty"`
+}
+
+// InGeneration returns the move as it was in generation gen, without its
+// past values. Before generation 4, a damaging move's category follows its
+// type. A gen outside 1 to LatestGeneration means the latest games.
+func (m Move) InGeneration(gen int) Move {
+	out := m
+	out.Past = nil
+	if gen < 1 || gen >= LatestGeneration {
+		return out
+	}
+	for i := len(m.Past) - 1; i >= 0; i-- {
+		p := m.Past[i]
+		if p.Generation < gen {
+			break
+		}
+		if p.Type != "" {
+			out.Type = p.Type
+		}
+		if p.Power != nil {
+			out.Power = p.Power
+		}
+		if p.Accuracy != nil {
+			out.Accuracy = p.Accuracy
+		}
+		if p.PP != 0 {
+			out.PP = p.PP
+		}
+		if p.Effect != "" {
+			out.Effect = p.Effect
+		}
+	}
+	if gen < splitGeneration && out.Category != CategoryStatus {
+		out.Category = CategoryPhysical
+		if specialTypes[out.Type] {
+			out.Category = CategorySpecial
+		}
+	}
+	return out
+}
+
+// Validate reports a document the API could not serve.
+func (m Move) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(m.Type) == "" {
+		return fmt.Errorf("%s: type is required", m.Name)
+	}
+	switch m.Category {
+	case CategoryPhysical, CategorySpecial:
+		// Moves with fixed or level-based damage, such as Seismic Toss,
+		// have no power.
+		if m.Power != nil && *m.Power < 1 {
+			return fmt.Errorf("%s: power %d out of range", m.Name, *m.Power)
+		}
+	case CategoryStatus:
+		if m.Power != nil {
+			return fmt.Errorf("%s: status move with power", m.Name)
+		}
+	default:
+		return fmt.Errorf("%s: unknown category %q", m.Name, m.Category)
+	}
+	if m.Accuracy != nil && (*m.Accuracy < 1 || *m.Accuracy > 100) {
+		return fmt.Errorf("%s: accuracy %d out of range", m.Name, *m.Accuracy)
+	}
+	if m.PP < 1 {
+		return fmt.Errorf("%s: pp %d out of range", m.Name, m.PP)
+	}
+	for i, p := range m.Past {
+		if p.Generation < 1 || p.Generation >= LatestGeneration || (i > 0 && p.Generation <= m.Past[i-1].Generation) {
+			return fmt.Errorf("%s: past values of generation %d out of order", m.Name, p.Generation)
+		}
+		if p.Power != nil && (m.Category == CategoryStatus || *p.Power < 1) {
+			return fmt.Errorf("%s: generation %d power %d out of range", m.Name, p.Generation, *p.Power)
+		}
+		if p.Accuracy != nil && (*p.Accuracy < 1 || *p.Accuracy > 100) {
+			return fmt.Errorf("%s: generation %d accuracy %d out of range", m.Name, p.Generation, *p.Accuracy)
+		}
+		if p.PP < 0 {
+			return fmt.Errorf("%s: generation %d pp %d out of range", m.Name, p.Generation, p.PP)
+		}
+	}
+	return nil
+}
+
+// Ways a Pokemon learns a move.
+const (
+	LearnLevelUp = "level-up" // at Level, or when evolving for level 0
+	LearnMachine = "machine"  // from a TM or HM, named by Machine
+	LearnEgg     = "egg"      // inherited from a parent
+	LearnTutor   = "tutor"    // taught by a move tutor
+)
+
+// LearnedMove is one move of a learnset and how it is learned.
+type LearnedMove struct {
+	Move    string `json:"move" bson:"move"`
+	Method  string `json:"method" bson:"method"`
+	Level   int    `json:"level,omitempty" bson:"level,omitempty"`     // for LearnLevelUp; 1 means known from the start
+	Machine string `json:"machine,omitempty" bson:"machine,omitempty"` // for LearnMachine, e.g. TM24 or HM01
+}
+
+// Learnset struct สำหรับ MongoDB: ท่าทั้งหมดที่ Pokemon หนึ่งตัวเรียนได้ในเกมหนึ่งเวอร์ชัน
+type Learnset struct {
+	DexNumber string        `json:"dex_number" bson:"dex_number"` // รูปแบบเดียวกับ dataset เช่น "#0025"
+	Version   string        `json:"version" bson:"version"`       // กลุ่มเวอร์ชันของเกมใน VersionGroups เช่น red-blue
+	Moves     []LearnedMove `json:"moves" bson:"moves"`
+}
+
+// Validate reports a document the API could not serve. Move names are
+// checked against the moves collection by the importer.
+func (l Learnset) Validate() error {
+	if strings.TrimSpace(l.DexNumber) == "" || strings.TrimSpace(l.Version) == "" {
+		return errors.New("dex_number and version are required")
+	}
+	if VersionGroups[l.Version] == 0 {
+		return fmt.Errorf("%s: unknown version group %q", l.DexNumber, l.Version)
+	}
+	for _, m := range l.Moves {
+		if strings.TrimSpace(m.Move) == "" {
+			return fmt.Errorf("%s %s: move name is required", l.DexNumber, l.Version)
+		}
+		switch m.Method {
+		case LearnLevelUp:
+			if m.Level < 0 || m.Level > 100 {
+				return fmt.Errorf("%s %s: %s at level %d", l.DexNumber, l.Version, m.Move, m.Level)
+			}
+		case LearnMachine:
+			if m.Machine == "" {
+				return fmt.Errorf("%s %s: %s from a machine without its number", l.DexNumber, l.Version, m.Move)
+			}
+		case LearnEgg, LearnTutor:
+		default:
+			return fmt.Errorf("%s %s: %s has unknown method %q", l.DexNumber, l.Version, m.Move, m.Method)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package models holds the documents stored in MongoDB and returned by the API.
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Pokemon struct สำหรับ MongoDB
+type Pokemon struct {
+	DexNumber     string `json:"dex_number" bson:"dex_number"`
+	Name          string `json:"name" bson:"name"`
+	Type01        string `json:"type_01" bson:"type_01"`
+	Type02        string `json:"type_02" bson:"type_02"`
+	Ability01     string `json:"ability_01" bson:"ability_01"`
+	Ability02     string `json:"ability_02" bson:"ability_02"`
+	HiddenAbility string `json:"hidden_ability" bson:"hidden_ability"`
+	EggGroup01    string `json:"egg_group_01" bson:"egg_group_01"` // ว่างสำหรับ Pokemon ที่ไม่มีข้อมูล egg group (เช่น legendary)
+	EggGroup02    string `json:"egg_group_02" bson:"egg_group_02"`
+	IsLegendary   string `json:"is_legendary" bson:"is_legendary"`
+	Bio           string `json:"bio" bson:"bio"`
+	HP            string `json:"hp" bson:"hp"`
+	Attack        string `json:"attack" bson:"attack"`
+	Defense       string `json:"defense" bson:"defense"`
+	SpAttack      string `json:"sp_attack" bson:"sp_attack"`
+	SpDefense     string `json:"sp_defense" bson:"sp_defense"`
+	Speed         string `json:"speed" bson:"speed"`
+}
+
+// BaseStats is the numeric form of a Pokemon's stat fields.
+type BaseStats struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"sp_attack"`
+	SpDefense int `json:"sp_defense"`
+	Speed     int `json:"speed"`
+	Total     int `json:"total"` // sum of the six stats
+}
+
+// Number returns the national dex number, e.g. 25 for "#0025", or 0 when the
+// stored value is malformed.
+func (p Pokemon) Number() int {
+	n, _ := strconv.Atoi(strings.TrimLeft(strings.TrimSpace(p.DexNumber), "#"))
+	return n
+}
+
+// Types returns the one or two types, primary first, without the stray
+// whitespace found in the datasets.
+func (p Pokemon) Types() []string {
+	return nonEmpty(p.Type01, p.Type02)
+}
+
+// Abilities returns the regular abilities, without the hidden ability.
+func (p Pokemon) Abilities() []string {
+	return nonEmpty(p.Ability01, p.Ability02)
+}
+
+// EggGroups returns the one or two egg groups. A dataset value may carry a
+// note about a special form after an escaped non-breaking space ("\xa0"
+// as literal text); the note is dropped.
+func (p Pokemon) EggGroups() []string {
+	groups := nonEmpty(p.EggGroup01, p.EggGroup02)
+	for i, g := range groups {
+		if before, _, ok := strings.Cut(g, `\xa0`); ok {
+			groups[i] = strings.TrimSpace(before)
+		}
+	}
+	return groups
+}
+
+// Legendary reports whether the dataset marks the Pokemon as legendary.
+func (p Pokemon) Legendary() bool {
+	return strings.EqualFold(strings.TrimSpace(p.IsLegendary), "True")
+}
+
+// BaseStats parses the stat fields; a non-numeric value counts as 0.
+func (p Pokemon) BaseStats() BaseStats {
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(strings.TrimSpace(s))
+		return n
+	}
+	b := BaseStats{
+		HP:        atoi(p.HP),
+		Attack:    atoi(p.Attack),
+		Defense:   atoi(p.Defense),
+		SpAttack:  atoi(p.SpAttack),
+		SpDefense: atoi(p.SpDefense),
+		Speed:     atoi(p.Speed),
+	}
+	b.Total = b.HP + b.Attack + b.Defense + b.SpAttack + b.SpDefense + b.Speed
+	return b
+}
+
+func nonEmpty(values ...string) []string {
+	out := []string{}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
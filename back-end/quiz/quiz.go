@@ -0,0 +1,407 @@
+// Package quiz generates "Who's that Pokemon?" questions from the datasets:
+// guessing a Pokemon from its bio or from its types and base stats, and
+// picking which of two Pokemon has the higher base stat.
+package quiz
+
+import (
+	"GO-Mongo/models"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Redacted replaces the words a bio question hides.
+const Redacted = "_____"
+
+// maxAttempts bounds the random draws for a higher/lower pair far enough
+// apart for the difficulty.
+const maxAttempts = 200
+
+var (
+	// ErrUnknownKind is returned for a question kind that does not exist.
+	ErrUnknownKind = errors.New("unknown question kind")
+	// ErrUnknownDifficulty is returned for a difficulty that does not exist.
+	ErrUnknownDifficulty = errors.New("unknown difficulty")
+	// ErrPoolTooSmall is returned when the pool cannot fill a question.
+	ErrPoolTooSmall = errors.New("not enough Pokemon for a question")
+)
+
+// Kind is a kind of question.
+type Kind string
+
+// Question kinds.
+const (
+	KindBio         Kind = "bio"          // the bio, with the name redacted
+	KindStats       Kind = "stats"        // the types and base stats
+	KindHigherLower Kind = "higher_lower" // which of two has the higher stat
+)
+
+// Kinds lists every question kind.
+var Kinds = []Kind{KindBio, KindStats, KindHigherLower}
+
+// ParseKind matches s case-insensitively against Kinds.
+func ParseKind(s string) (Kind, error) {
+	for _, k := range Kinds {
+		if strings.EqualFold(strings.TrimSpace(s), string(k)) {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownKind, s)
+}
+
+// Difficulty sets how many choices a question offers, how much it gives
+// away and how many points a correct answer is worth.
+type Difficulty string
+
+// Difficulties.
+const (
+	Easy   Difficulty = "easy"
+	Normal Difficulty = "normal"
+	Hard   Difficulty = "hard"
+)
+
+// Difficulties lists every difficulty, easiest first.
+var Difficulties = []Difficulty{Easy, Normal, Hard}
+
+// ParseDifficulty matches s case-insensitively against Difficulties. An
+// empty s is Normal.
+func ParseDifficulty(s string) (Difficulty, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Normal, nil
+	}
+	for _, d := range Difficulties {
+		if strings.EqualFold(s, string(d)) {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownDifficulty, s)
+}
+
+// level is what a difficulty changes.
+type level struct {
+	choices   int  // options offered when guessing a Pokemon
+	points    int  // awarded for a correct answer, before the streak bonus
+	sentences int  // of the bio shown
+	minGap    int  // between the two stats of a higher/lower question
+	lookalike bool // wrong choices share a type with the answer
+	showTypes bool // in stats questions
+	hideMore  bool // redact types and other Pokemon's names in bios
+}
+
+var levels = map[Difficulty]level{
+	Easy:   {choices: 3, points: 1, sentences: 6, minGap: 30, showTypes: true},
+	Normal: {choices: 4, points: 2, sentences: 4, minGap: 15, lookalike: true, showTypes: true},
+	Hard:   {choices: 6, points: 3, sentences: 3, minGap: 1, lookalike: true, hideMore: true},
+}
+
+// Points returns what a correct answer is worth at d before the streak
+// bonus.
+func (d Difficulty) Points() int {
+	return levels[d].points
+}
+
+// stat is a stat a higher/lower question compares, with its display name.
+type stat struct {
+	name, label string
+	value       func(models.BaseStats) int
+}
+
+var higherLowerStats = []stat{
+	{"hp", "HP", func(b models.BaseStats) int { return b.HP }},
+	{"attack", "Attack", func(b models.BaseStats) int { return b.Attack }},
+	{"defense", "Defense", func(b models.BaseStats) int { return b.Defense }},
+	{"sp_attack", "Sp. Atk", func(b models.BaseStats) int { return b.SpAttack }},
+	{"sp_defense", "Sp. Def", func(b models.BaseStats) int { return b.SpDefense }},
+	{"speed", "Speed", func(b models.BaseStats) int { return b.Speed }},
+	{"total", "base stat total", func(b models.BaseStats) int { return b.Total }},
+}
+
+// Question is what the player sees; the answer stays on the server.
+type Question struct {
+	Number  int               `json:"number" doc:"1 for the first question of a session"`
+	Kind    Kind              `json:"kind" doc:"bio, stats or higher_lower"`
+	Prompt  string            `json:"prompt"`
+	Bio     string            `json:"bio,omitempty" doc:"The start of the bio, names redacted; bio questions only"`
+	Types   []string          `json:"types,omitempty" doc:"Stats questions below hard only"`
+	Stats   *models.BaseStats `json:"stats,omitempty" doc:"Stats questions only"`
+	Stat    string            `json:"stat,omitempty" doc:"The compared stat; higher/lower questions only"`
+	Choices []string          `json:"choices" doc:"Pokemon names; answer with one of them"`
+
+	answer      string
+	explanation string
+}
+
+// Pool is the set of Pokemon questions are drawn from. It is read-only once
+// built, so one pool can serve every session over the same Pokemon.
+type Pool struct {
+	pokemons []models.Pokemon
+	withBio  []int // indexes of pokemons with a bio
+	names    *regexp.Regexp
+	types    *regexp.Regexp
+}
+
+// NewPool returns a pool of pokemons in dex order, keeping one Pokemon per
+// name.
+func NewPool(pokemons []models.Pokemon) *Pool {
+	p := &Pool{}
+	seen := map[string]bool{}
+	sorted := slices.Clone(pokemons)
+	slices.SortStableFunc(sorted, func(a, b models.Pokemon) int { return a.Number() - b.Number() })
+
+	var names, types []string
+	for _, pk := range sorted {
+		name := strings.ToLower(strings.TrimSpace(pk.Name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if strings.TrimSpace(pk.Bio) != "" {
+			p.withBio = append(p.withBio, len(p.pokemons))
+		}
+		p.pokemons = append(p.pokemons, pk)
+		names = append(names, withStones(strings.TrimSpace(pk.Name))...)
+		for _, t := range pk.Types() {
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	p.names = alternation(names, true)
+	p.types = alternation(types, false)
+	return p
+}
+
+// Len returns the number of Pokemon in the pool.
+func (p *Pool) Len() int {
+	return len(p.pokemons)
+}
+
+// Next draws a question of kind at d.
+func (p *Pool) Next(r *rand.Rand, kind Kind, d Difficulty) (Question, error) {
+	lv, ok := levels[d]
+	if !ok {
+		return Question{}, fmt.Errorf("%w %q", ErrUnknownDifficulty, d)
+	}
+	switch kind {
+	case KindBio:
+		return p.bioQuestion(r, lv)
+	case KindStats:
+		return p.statsQuestion(r, lv)
+	case KindHigherLower:
+		return p.higherLowerQuestion(r, lv)
+	}
+	return Question{}, fmt.Errorf("%w %q", ErrUnknownKind, kind)
+}
+
+func (p *Pool) bioQuestion(r *rand.Rand, lv level) (Question, error) {
+	if len(p.withBio) == 0 || len(p.pokemons) < lv.choices {
+		return Question{}, ErrPoolTooSmall
+	}
+	answer := p.pokemons[p.withBio[r.IntN(len(p.withBio))]]
+
+	bio := redact(answer.Bio, alternation(withStones(strings.TrimSpace(answer.Name)), true), true)
+	if lv.hideMore {
+		bio = redact(redact(bio, p.names, true), p.types, false)
+	}
+	return Question{
+		Kind:        KindBio,
+		Prompt:      "Who's that Pokemon?",
+		Bio:         firstSentences(bio, lv.sentences),
+		Choices:     p.choices(r, answer, lv),
+		answer:      strings.TrimSpace(answer.Name),
+		explanation: "It's " + strings.TrimSpace(answer.Name) + "!",
+	}, nil
+}
+
+func (p *Pool) statsQuestion(r *rand.Rand, lv level) (Question, error) {
+	if len(p.pokemons) < lv.choices {
+		return Question{}, ErrPoolTooSmall
+	}
+	answer := p.pokemons[r.IntN(len(p.pokemons))]
+	stats := answer.BaseStats()
+	q := Question{
+		Kind:        KindStats,
+		Prompt:      "Which Pokemon has these base stats?",
+		Stats:       &stats,
+		Choices:     p.choices(r, answer, lv),
+		answer:      strings.TrimSpace(answer.Name),
+		explanation: "It's " + strings.TrimSpace(answer.Name) + "!",
+	}
+	if lv.showTypes {
+		q.Types = answer.Types()
+		q.Prompt = "Which Pokemon has these types and base stats?"
+	}
+	return q, nil
+}
+
+func (p *Pool) higherLowerQuestion(r *rand.Rand, lv level) (Question, error) {
+	if len(p.pokemons) < 2 {
+		return Question{}, ErrPoolTooSmall
+	}
+	compared := higherLowerStats[r.IntN(len(higherLowerStats))]
+	for range maxAttempts {
+		a, b := p.pokemons[r.IntN(len(p.pokemons))], p.pokemons[r.IntN(len(p.pokemons))]
+		va, vb := compared.value(a.BaseStats()), compared.value(b.BaseStats())
+		if gap := va - vb; gap < lv.minGap && -gap < lv.minGap || va == vb {
+			continue
+		}
+		winner := a
+		if vb > va {
+			winner = b
+		}
+		return Question{
+			Kind:    KindHigherLower,
+			Prompt:  "Which has the higher " + compared.label + "?",
+			Stat:    compared.name,
+			Choices: []string{strings.TrimSpace(a.Name), strings.TrimSpace(b.Name)},
+			answer:  strings.TrimSpace(winner.Name),
+			explanation: fmt.Sprintf("%s has %d %s, %s has %d",
+				strings.TrimSpace(a.Name), va, compared.label, strings.TrimSpace(b.Name), vb),
+		}, nil
+	}
+	return Question{}, ErrPoolTooSmall
+}
+
+// choices returns the answer's name and lv.choices-1 other names, shuffled.
+// Lookalike levels draw the others from the Pokemon sharing a type with the
+// answer first.
+func (p *Pool) choices(r *rand.Rand, answer models.Pokemon, lv level) []string {
+	var near, far []string
+	for _, pk := range p.pokemons {
+		if strings.EqualFold(strings.TrimSpace(pk.Name), strings.TrimSpace(answer.Name)) {
+			continue
+		}
+		if lv.lookalike && sharesType(pk, answer) {
+			near = append(near, strings.TrimSpace(pk.Name))
+		} else {
+			far = append(far, strings.TrimSpace(pk.Name))
+		}
+	}
+	r.Shuffle(len(near), func(i, j int) { near[i], near[j] = near[j], near[i] })
+	r.Shuffle(len(far), func(i, j int) { far[i], far[j] = far[j], far[i] })
+
+	out := append([]string{strings.TrimSpace(answer.Name)}, append(near, far...)[:lv.choices-1]...)
+	r.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out
+}
+
+func sharesType(a, b models.Pokemon) bool {
+	for _, t := range a.Types() {
+		if slices.Contains(b.Types(), t) {
+			return true
+		}
+	}
+	return false
+}
+
+// megaStones are the Mega Stones whose names do not start with their
+// Pokemon's name, such as the Blastoisinite; the others are caught as stems
+// of the name.
+var megaStones = map[string]string{
+	"alakazam":  "Alakazite",
+	"blastoise": "Blastoisinite",
+	"heracross": "Heracronite",
+}
+
+// withStones returns name and, if it is irregular, its Mega Stone.
+func withStones(name string) []string {
+	if stone, ok := megaStones[strings.ToLower(name)]; ok {
+		return []string{name, stone}
+	}
+	return []string{name}
+}
+
+// alternation matches any of words, longest first so "Mewtwo" wins over
+// "Mew". Names match case-insensitively; types match only capitalized, so
+// hiding the Fire type leaves "breathes fire" alone.
+func alternation(words []string, fold bool) *regexp.Regexp {
+	sorted := slices.Clone(words)
+	slices.SortFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+	quoted := make([]string, 0, len(sorted))
+	for _, w := range sorted {
+		if w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	expr := strings.Join(quoted, "|")
+	if fold {
+		expr = `(?i)` + expr
+	}
+	return regexp.MustCompile(expr)
+}
+
+// redact replaces the matches of re in text with Redacted. A match must
+// start a word, so "Abra" inside "Kadabra" is kept. With stems, a match
+// followed by letters is redacted up to the end of the word, which hides
+// Mega Stones such as "Kangaskhanite"; otherwise it must end the word too,
+// so hiding the Normal type keeps "Normally".
+func redact(text string, re *regexp.Regexp, stems bool) string {
+	if re == nil {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		if m[0] < last {
+			continue // inside a word redacted already
+		}
+		before, _ := utf8.DecodeLastRuneInString(text[:m[0]])
+		if isWordRune(before) {
+			continue
+		}
+		end := m[1]
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !isWordRune(r) {
+				break
+			}
+			if !stems {
+				end = -1
+				break
+			}
+			end += size
+		}
+		if end < 0 {
+			continue
+		}
+		b.WriteString(text[last:m[0]])
+		b.WriteString(Redacted)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// firstSentences returns the first n sentences of text. A sentence ends at
+// a period followed by a space and a capital letter, which keeps "Mime Jr.
+// evolves" together.
+func firstSentences(text string, n int) string {
+	text = strings.TrimSpace(text)
+	count := 0
+	for i := 0; i+2 < len(text); i++ {
+		if text[i] != '.' || text[i+1] != ' ' {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(text[i+2:])
+		if !unicode.IsUpper(next) && !strings.HasPrefix(text[i+2:], Redacted) {
+			continue
+		}
+		if count++; count == n {
+			return text[:i+1]
+		}
+	}
+	return text
+}
@@ -0,0 +1,243 @@
+package quiz
+
+import (
+	"GO-Mongo/internal/fixture"
+	"GO-Mongo/models"
+	"errors"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name, text string
+		words      []string
+		fold       bool
+		want       string
+	}{
+		{"possessive", "Bulbasaur's bulb grows.", []string{"Bulbasaur"}, true, "_____'s bulb grows."},
+		{"start of word only", "Kadabra and Abra.", []string{"Abra"}, true, "Kadabra and _____."},
+		{"longest first", "Mewtwo and Mew.", []string{"Mew", "Mewtwo"}, true, "_____ and _____."},
+		{"punctuated name", "Mr. Mime is a Psychic-type.", []string{"Mr. Mime"}, true, "_____ is a Psychic-type."},
+		{"case-insensitive", "PIKACHU!", []string{"Pikachu"}, true, "_____!"},
+		{"types keep case", "A Fire-type that breathes fire.", []string{"Fire"}, false, "A _____-type that breathes fire."},
+		{"types are whole words", "Normally a Normal-type.", []string{"Normal"}, false, "Normally a _____-type."},
+		{"mega stone", "Using the Kangaskhanite, it Mega Evolves.", []string{"Kangaskhan"}, true, "Using the _____, it Mega Evolves."},
+		{"mega stone X", "Charizardite X and Charizardite Y.", []string{"Charizard"}, true, "_____ X and _____ Y."},
+		{"irregular mega stone", "Holding the Blastoisinite.", withStones("Blastoise"), true, "Holding the _____."},
+	}
+
+	// Every Mega Stone mentioned by the bios must be hidden, both as the
+	// answer's name and, on hard, among the names of the whole pool.
+	stones := map[string]string{
+		"Venusaur": "Venusaurite", "Charizard": "Charizardite", "Blastoise": "Blastoisinite",
+		"Beedrill": "Beedrillite", "Pidgeot": "Pidgeotite", "Slowbro": "Slowbronite",
+		"Kangaskhan": "Kangaskhanite", "Pinsir": "Pinsirite", "Gyarados": "Gyaradosite",
+		"Aerodactyl": "Aerodactylite", "Ampharos": "Ampharosite", "Heracross": "Heracronite",
+		"Houndoom": "Houndoominite", "Tyranitar": "Tyranitarite",
+	}
+	var pokemons []models.Pokemon
+	for name := range stones {
+		pokemons = append(pokemons, models.Pokemon{Name: name})
+	}
+	pool := NewPool(pokemons)
+	for name, stone := range stones {
+		text := "It Mega Evolves using the " + stone + "."
+		want := "It Mega Evolves using the _____."
+		if got := redact(text, alternation(withStones(name), true), true); got != want {
+			t.Errorf("redact(%q) with %s = %q, want %q", text, name, got, want)
+		}
+		if got := redact(text, pool.names, true); got != want {
+			t.Errorf("redact(%q) with every name = %q, want %q", text, got, want)
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redact(tt.text, alternation(tt.words, tt.fold), tt.fold); got != tt.want {
+				t.Errorf("redact = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstSentences(t *testing.T) {
+	text := "It evolves from Mime Jr. when leveled up. It is a Pokémon. It mimes. _____ is shy."
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "It evolves from Mime Jr. when leveled up."},
+		{3, "It evolves from Mime Jr. when leveled up. It is a Pokémon. It mimes."},
+		{9, text},
+	}
+	for _, tt := range tests {
+		if got := firstSentences(text, tt.n); got != tt.want {
+			t.Errorf("firstSentences(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	for in, want := range map[string]Difficulty{"": Normal, "EASY": Easy, " hard ": Hard} {
+		if got, err := ParseDifficulty(in); err != nil || got != want {
+			t.Errorf("ParseDifficulty(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseDifficulty("extreme"); !errors.Is(err, ErrUnknownDifficulty) {
+		t.Errorf("ParseDifficulty(extreme) error = %v, want ErrUnknownDifficulty", err)
+	}
+}
+
+func TestNext(t *testing.T) {
+	kanto := fixture.Dataset(t, "kanto")
+	pool := NewPool(append(kanto, kanto[:10]...))
+	if pool.Len() != 151 {
+		t.Fatalf("Len = %d, want 151 (duplicate names kept once)", pool.Len())
+	}
+	byName := map[string]models.Pokemon{}
+	for _, p := range kanto {
+		byName[strings.TrimSpace(p.Name)] = p
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, d := range Difficulties {
+		for _, k := range Kinds {
+			for range 20 {
+				q, err := pool.Next(r, k, d)
+				if err != nil {
+					t.Fatalf("Next(%s, %s): %v", k, d, err)
+				}
+				if !slices.Contains(q.Choices, q.answer) {
+					t.Fatalf("Next(%s, %s) choices %v miss the answer %q", k, d, q.Choices, q.answer)
+				}
+				answer := byName[q.answer]
+				switch k {
+				case KindBio:
+					if len(q.Choices) != levels[d].choices {
+						t.Fatalf("Next(%s, %s) has %d choices, want %d", k, d, len(q.Choices), levels[d].choices)
+					}
+					leak := regexp.MustCompile(`(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(q.answer))
+					if leak.MatchString(q.Bio) {
+						t.Fatalf("Next(%s, %s) bio %q names %q", k, d, q.Bio, q.answer)
+					}
+				case KindStats:
+					if len(q.Choices) != levels[d].choices {
+						t.Fatalf("Next(%s, %s) has %d choices, want %d", k, d, len(q.Choices), levels[d].choices)
+					}
+					if *q.Stats != answer.BaseStats() {
+						t.Fatalf("Next(%s, %s) stats %+v, want %s's %+v", k, d, *q.Stats, q.answer, answer.BaseStats())
+					}
+					if (len(q.Types) > 0) != levels[d].showTypes {
+						t.Fatalf("Next(%s, %s) types = %v", k, d, q.Types)
+					}
+				case KindHigherLower:
+					// The answer must have the higher value of the named
+					// stat, by at least the difficulty's gap.
+					i := slices.IndexFunc(higherLowerStats, func(s stat) bool { return s.name == q.Stat })
+					if i < 0 || len(q.Choices) != 2 {
+						t.Fatalf("Next(%s, %s) = %+v", k, d, q)
+					}
+					a, b := byName[q.Choices[0]], byName[q.Choices[1]]
+					va, vb := higherLowerStats[i].value(a.BaseStats()), higherLowerStats[i].value(b.BaseStats())
+					winner := q.Choices[0]
+					if vb > va {
+						winner = q.Choices[1]
+					}
+					if q.answer != winner || max(va-vb, vb-va) < levels[d].minGap {
+						t.Fatalf("Next(%s, %s) %s: %s %d vs %s %d, answer %s", k, d, q.Stat, a.Name, va, b.Name, vb, q.answer)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSessionScoring(t *testing.T) {
+	s, err := newSession("test", NewPool(fixture.Dataset(t, "kanto")), Options{Difficulty: Hard, Length: 4}, rand.New(rand.NewPCG(3, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answer := func(correct bool) Outcome {
+		t.Helper()
+		q := s.question
+		a := q.answer
+		if !correct {
+			a = "MissingNo."
+		}
+		out, err := s.Answer(q.Number, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+	wantPoints := []int{3, 4, 0, 3}
+	for i, correct := range []bool{true, true, false, true} {
+		if out := answer(correct); out.Result.Points != wantPoints[i] || out.Result.Correct != correct {
+			t.Errorf("answer %d = %+v, want correct %v for %d points", i+1, out.Result, correct, wantPoints[i])
+		}
+	}
+
+	got := s.State()
+	if got.Score != 10 || got.Correct != 3 || got.Streak != 1 || got.BestStreak != 2 || !got.Finished || got.Question != nil {
+		t.Errorf("final state = %+v", got)
+	}
+	if _, err := s.Answer(5, "Mew"); !errors.Is(err, ErrFinished) {
+		t.Errorf("Answer after the end error = %v, want ErrFinished", err)
+	}
+}
+
+func TestSessionRejectsOtherQuestions(t *testing.T) {
+	s, err := newSession("test", NewPool(fixture.Dataset(t, "kanto")), Options{Difficulty: Easy, Kinds: []Kind{KindStats}}, rand.New(rand.NewPCG(5, 6)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Answer(2, "Mew"); !errors.Is(err, ErrNotCurrent) {
+		t.Errorf("Answer(2) error = %v, want ErrNotCurrent", err)
+	}
+	if _, err := s.Answer(1, "Mew"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Answer(1, "Mew"); !errors.Is(err, ErrNotCurrent) {
+		t.Errorf("answering question 1 twice error = %v, want ErrNotCurrent", err)
+	}
+	if q := s.State().Question; q == nil || q.answer != "" || q.Kind != KindStats {
+		t.Errorf("State().Question = %+v, want a stats question without its answer", q)
+	}
+}
+
+func TestNewSessionPoolTooSmall(t *testing.T) {
+	pool := NewPool(fixture.Dataset(t, "kanto")[:4])
+	if _, err := newSession("test", pool, Options{Difficulty: Hard}, rand.New(rand.NewPCG(7, 8))); !errors.Is(err, ErrPoolTooSmall) {
+		t.Errorf("newSession error = %v, want ErrPoolTooSmall", err)
+	}
+}
+
+func TestSessionAnswerKeepsQuestionWhenDrawFails(t *testing.T) {
+	kanto := fixture.Dataset(t, "kanto")
+	s, err := newSession("test", NewPool(kanto), Options{Difficulty: Easy}, rand.New(rand.NewPCG(9, 10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := s.question
+	pool := s.pool
+	s.pool = NewPool(kanto[:1])
+	if _, err := s.Answer(q.Number, q.answer); !errors.Is(err, ErrPoolTooSmall) {
+		t.Fatalf("Answer error = %v, want ErrPoolTooSmall", err)
+	}
+	if got := s.State(); got.Answered != 0 || got.Score != 0 || got.Streak != 0 || got.Question.Number != q.Number {
+		t.Fatalf("state after a failed draw = %+v, want question %d unanswered", got, q.Number)
+	}
+
+	s.pool = pool
+	out, err := s.Answer(q.Number, q.answer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Result.Correct || out.Session.Score != Easy.Points() || out.Session.Question.Number != q.Number+1 {
+		t.Errorf("answering again = %+v", out)
+	}
+}
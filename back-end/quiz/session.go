@@ -0,0 +1,255 @@
+package quiz
+
+import (
+	"GO-Mongo/cache"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxLength is the most questions a session can be limited to.
+const MaxLength = 50
+
+// maxStreakBonus caps the points a streak adds to a correct answer.
+const maxStreakBonus = 5
+
+var (
+	// ErrNotFound is returned for a session that never existed or expired.
+	ErrNotFound = errors.New("quiz session not found")
+	// ErrInvalidLength is returned for a length outside 0 to MaxLength.
+	ErrInvalidLength = errors.New("invalid quiz length")
+	// ErrFinished is returned when answering a finished session.
+	ErrFinished = errors.New("quiz session is finished")
+	// ErrNotCurrent is returned when answering a question other than the
+	// current one, such as one answered already.
+	ErrNotCurrent = errors.New("not the current question")
+)
+
+// Options configures a new session.
+type Options struct {
+	Difficulty Difficulty
+	// Kinds are drawn from at random for each question; empty means every
+	// kind.
+	Kinds []Kind
+	// Length is the number of questions; 0 means the session never ends.
+	Length int
+}
+
+// State is a session as the player sees it.
+type State struct {
+	ID         string     `json:"id"`
+	Difficulty Difficulty `json:"difficulty"`
+	Kinds      []Kind     `json:"kinds"`
+	Length     int        `json:"length,omitempty" doc:"Number of questions; absent when the session never ends"`
+	Answered   int        `json:"answered"`
+	Correct    int        `json:"correct"`
+	Score      int        `json:"score"`
+	Streak     int        `json:"streak" doc:"Correct answers in a row, up to the last one"`
+	BestStreak int        `json:"best_streak"`
+	Finished   bool       `json:"finished"`
+	Question   *Question  `json:"question,omitempty" doc:"The question to answer next; absent once finished"`
+}
+
+// Result is the verdict on one answer.
+type Result struct {
+	Correct     bool   `json:"correct"`
+	Answer      string `json:"answer" doc:"The right choice"`
+	Explanation string `json:"explanation"`
+	Points      int    `json:"points" doc:"Awarded for this answer, streak bonus included"`
+}
+
+// Outcome is a Result and the session after it.
+type Outcome struct {
+	Result  Result `json:"result"`
+	Session State  `json:"session"`
+}
+
+// Points returns what a correct answer is worth at d when it makes a streak
+// of streak: the difficulty's points plus one for every earlier answer of
+// the streak, up to maxStreakBonus.
+func Points(d Difficulty, streak int) int {
+	return d.Points() + max(0, min(streak-1, maxStreakBonus))
+}
+
+// Session is one player's run of questions. It is safe for concurrent use.
+type Session struct {
+	mu       sync.Mutex
+	state    State
+	question Question // the current question, with its answer
+	pool     *Pool    // shared with other sessions
+	rand     *mathrand.Rand
+}
+
+func newSession(id string, pool *Pool, opts Options, r *mathrand.Rand) (*Session, error) {
+	if _, ok := levels[opts.Difficulty]; !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownDifficulty, opts.Difficulty)
+	}
+	if opts.Length < 0 || opts.Length > MaxLength {
+		return nil, fmt.Errorf("%w %d: must be between 0 and %d", ErrInvalidLength, opts.Length, MaxLength)
+	}
+	kinds := slices.Clone(opts.Kinds)
+	if len(kinds) == 0 {
+		kinds = slices.Clone(Kinds)
+	}
+	slices.Sort(kinds)
+	kinds = slices.Compact(kinds)
+	// Draw one question of every kind up front, so a pool too small for a
+	// kind fails now rather than halfway through the session.
+	for _, k := range kinds {
+		if _, err := pool.Next(r, k, opts.Difficulty); err != nil {
+			return nil, fmt.Errorf("%s questions: %w", k, err)
+		}
+	}
+
+	s := &Session{
+		state: State{ID: id, Difficulty: opts.Difficulty, Kinds: kinds, Length: opts.Length},
+		pool:  pool,
+		rand:  r,
+	}
+	q, err := s.draw()
+	if err != nil {
+		return nil, err
+	}
+	s.question = q
+	return s, nil
+}
+
+// State returns a snapshot of the session.
+func (s *Session) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshot()
+}
+
+// Answer checks answer against question number, which must be the current
+// question, and moves on to the next one. Answers match choices
+// case-insensitively.
+func (s *Session) Answer(number int, answer string) (Outcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state.Finished {
+		return Outcome{}, ErrFinished
+	}
+	if number != s.question.Number {
+		return Outcome{}, fmt.Errorf("question %d: %w %d", number, ErrNotCurrent, s.question.Number)
+	}
+
+	// Draw the next question before scoring, so a failed draw leaves the
+	// current question answerable and the score untouched.
+	finished := s.state.Length > 0 && s.state.Answered+1 >= s.state.Length
+	var next Question
+	if !finished {
+		var err error
+		if next, err = s.draw(); err != nil {
+			return Outcome{}, err
+		}
+	}
+
+	result := Result{
+		Correct:     strings.EqualFold(strings.TrimSpace(answer), s.question.answer),
+		Answer:      s.question.answer,
+		Explanation: s.question.explanation,
+	}
+	s.state.Answered++
+	if result.Correct {
+		s.state.Correct++
+		s.state.Streak++
+		s.state.BestStreak = max(s.state.BestStreak, s.state.Streak)
+		result.Points = Points(s.state.Difficulty, s.state.Streak)
+		s.state.Score += result.Points
+	} else {
+		s.state.Streak = 0
+	}
+
+	if finished {
+		s.state.Finished = true
+	} else {
+		s.question = next
+	}
+	return Outcome{Result: result, Session: s.snapshot()}, nil
+}
+
+// draw returns the question after the current one. The caller holds s.mu,
+// unless s is not shared yet.
+func (s *Session) draw() (Question, error) {
+	kind := s.state.Kinds[s.rand.IntN(len(s.state.Kinds))]
+	q, err := s.pool.Next(s.rand, kind, s.state.Difficulty)
+	if err != nil {
+		return Question{}, err
+	}
+	q.Number = s.question.Number + 1
+	return q, nil
+}
+
+// snapshot copies the state for the player. The caller holds s.mu.
+func (s *Session) snapshot() State {
+	out := s.state
+	out.Kinds = slices.Clone(s.state.Kinds)
+	if !s.state.Finished {
+		q := s.question
+		q.Choices = slices.Clone(q.Choices)
+		q.answer, q.explanation = "", ""
+		out.Question = &q
+	}
+	return out
+}
+
+// Store keeps sessions in memory. Sessions expire ttl after they were
+// started or last answered, and the least recently used go first once size
+// sessions are open.
+type Store struct {
+	sessions *cache.LRU[string, *Session]
+}
+
+// NewStore returns an empty store.
+func NewStore(size int, ttl time.Duration) *Store {
+	return &Store{sessions: cache.NewLRU[string, *Session](size, ttl)}
+}
+
+// Start opens a session drawing its questions from pool. The session keeps
+// a reference to pool, which callers should share between sessions.
+func (st *Store) Start(pool *Pool, opts Options) (State, error) {
+	s, err := newSession(newID(), pool, opts, mathrand.New(mathrand.NewPCG(mathrand.Uint64(), mathrand.Uint64())))
+	if err != nil {
+		return State{}, err
+	}
+	st.sessions.Add(s.state.ID, s)
+	return s.State(), nil
+}
+
+// Get returns the session with id.
+func (st *Store) Get(id string) (State, error) {
+	s, ok := st.sessions.Get(id)
+	if !ok {
+		return State{}, ErrNotFound
+	}
+	return s.State(), nil
+}
+
+// Answer answers the current question of session id and extends the
+// session's lifetime.
+func (st *Store) Answer(id string, number int, answer string) (Outcome, error) {
+	s, ok := st.sessions.Get(id)
+	if !ok {
+		return Outcome{}, ErrNotFound
+	}
+	out, err := s.Answer(number, answer)
+	if err != nil {
+		return Outcome{}, err
+	}
+	st.sessions.Add(id, s)
+	return out, nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
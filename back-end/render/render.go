@@ -0,0 +1,159 @@
+// Package render writes handler results in the format selected by the
+// request's Accept header: JSON, MessagePack, CBOR or protobuf.
+package render
+
+import (
+	"GO-Mongo/apierror"
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Media types produced by Renderer.
+const (
+	JSON     = "application/json"
+	MsgPack  = "application/msgpack"
+	CBOR     = "application/cbor"
+	Protobuf = "application/x-protobuf"
+)
+
+// aliases maps other names clients use to the canonical media type.
+var aliases = map[string]string{
+	"application/x-msgpack":           MsgPack,
+	"application/vnd.msgpack":         MsgPack,
+	"application/protobuf":            Protobuf,
+	"application/vnd.google.protobuf": Protobuf,
+}
+
+// Renderer negotiates and writes responses. ToProto converts a handler result
+// to its protobuf message; results it does not know cannot be served as
+// protobuf.
+type Renderer struct {
+	ToProto func(v any) (proto.Message, bool)
+}
+
+// Render writes v with status in the best format the client accepts. When
+// none is acceptable it records a 406 error for the error middleware instead.
+// MessagePack and CBOR use the same field names as JSON.
+func (r Renderer) Render(c *gin.Context, status int, v any) {
+	offers := []string{JSON, MsgPack, CBOR}
+	var msg proto.Message
+	if r.ToProto != nil {
+		if m, ok := r.ToProto(v); ok {
+			msg = m
+			offers = append(offers, Protobuf)
+		}
+	}
+
+	mediaType, ok := Negotiate(c.GetHeader("Accept"), offers)
+	if !ok {
+		_ = c.Error(apierror.NotAcceptable("Acceptable media types are " + strings.Join(offers, ", ")))
+		return
+	}
+
+	var body []byte
+	var err error
+	switch mediaType {
+	case JSON:
+		c.JSON(status, v)
+		return
+	case MsgPack:
+		var buf bytes.Buffer
+		enc := msgpack.NewEncoder(&buf)
+		enc.SetCustomStructTag("json")
+		enc.SetOmitEmpty(false)
+		err = enc.Encode(v)
+		body = buf.Bytes()
+	case CBOR:
+		body, err = cbor.Marshal(v)
+	case Protobuf:
+		body, err = proto.Marshal(msg)
+	}
+	if err != nil {
+		_ = c.Error(apierror.Internal(err))
+		return
+	}
+	c.Data(status, mediaType, body)
+}
+
+// Negotiate picks the offer the Accept header prefers: highest q-value first,
+// then the most specific matching range, then the order of offers. An empty
+// header accepts anything.
+func Negotiate(accept string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	ranges := parseAccept(accept)
+	for _, offer := range offers {
+		// The most specific range matching an offer decides its q-value.
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := r.matches(offer); s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		if specificity < 0 || q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	return best, best != ""
+}
+
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// matches returns how specifically r matches mediaType: 2 for an exact
+// match, 1 for type/*, 0 for */* and -1 for no match.
+func (r mediaRange) matches(mediaType string) int {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	switch {
+	case r.typ == "*" && r.subtype == "*":
+		return 0
+	case r.typ == typ && r.subtype == "*":
+		return 1
+	case r.typ == typ && r.subtype == subtype:
+		return 2
+	}
+	return -1
+}
+
+func parseAccept(header string) []mediaRange {
+	var out []mediaRange
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if canonical, ok := aliases[mediaType]; ok {
+			mediaType = canonical
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || typ == "" || subtype == "" {
+			continue
+		}
+		r := mediaRange{typ: typ, subtype: subtype, q: 1}
+		for _, param := range fields[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil && q >= 0 && q <= 1 {
+					r.q = q
+				}
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package render
+
+import (
+	"GO-Mongo/apierror"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestNegotiate(t *testing.T) {
+	all := []string{JSON, MsgPack, CBOR, Protobuf}
+	tests := []struct {
+		name   string
+		accept string
+		offers []string
+		want   string // empty when nothing is acceptable
+	}{
+		{"empty header", "", all, JSON},
+		{"blank header", "  ", all, JSON},
+		{"no offers", "*/*", nil, ""},
+		{"exact", "application/cbor", all, CBOR},
+		{"case and spaces", " Application/CBOR ; q=1", all, CBOR},
+		{"alias", "application/x-msgpack", all, MsgPack},
+		{"protobuf alias", "application/vnd.google.protobuf", all, Protobuf},
+		{"any", "*/*", all, JSON},
+		{"type wildcard", "application/*", []string{MsgPack, CBOR}, MsgPack},
+		{"highest q wins", "application/json;q=0.5, application/cbor;q=0.8, application/msgpack;q=0.7", all, CBOR},
+		{"q beats order", "application/json;q=0.1, */*;q=0.2", all, MsgPack},
+		{"equal q goes to the most specific range", "*/*, application/cbor", all, CBOR},
+		{"equal q and specificity go to offer order", "application/cbor, application/msgpack", all, MsgPack},
+		// The most specific range decides an offer's q, even if a broader
+		// range gives it more.
+		{"specific range sets q", "application/*;q=1, application/json;q=0.1", []string{JSON, CBOR}, CBOR},
+		{"q=0 excludes", "application/json;q=0, */*", []string{JSON, CBOR}, CBOR},
+		{"q=0 on the only match", "application/json;q=0", all, ""},
+		{"wildcard q=0 keeps explicit ranges", "application/cbor, */*;q=0", all, CBOR},
+		{"invalid q counts as 1", "application/json;q=0.5, application/cbor;q=7", all, CBOR},
+		{"unsupported type", "text/html", all, ""},
+		{"malformed ranges are skipped", "json, /, application/cbor", all, CBOR},
+		{"only malformed", "garbage", all, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Negotiate(tt.accept, tt.offers)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("Negotiate(%q) = %q, %v, want %q", tt.accept, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+type pokemon struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+func TestRender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(apierror.Middleware())
+	renderer := Renderer{ToProto: func(v any) (proto.Message, bool) {
+		if p, ok := v.(pokemon); ok {
+			return wrapperspb.String(p.Name), true
+		}
+		return nil, false
+	}}
+	r.GET("/pokemon", func(c *gin.Context) { renderer.Render(c, http.StatusOK, pokemon{Name: "Pikachu", ID: 25}) })
+	r.GET("/count", func(c *gin.Context) { renderer.Render(c, http.StatusOK, map[string]int{"count": 151}) })
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	decoders := map[string]func([]byte, any) error{
+		JSON:    json.Unmarshal,
+		MsgPack: msgpack.Unmarshal,
+		CBOR:    cbor.Unmarshal,
+	}
+	for mediaType, decode := range decoders {
+		w := get("/pokemon", mediaType)
+		var got map[string]any
+		if err := decode(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: %v", mediaType, err)
+		}
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), mediaType) || got["name"] != "Pikachu" {
+			t.Errorf("%s: status %d, Content-Type %q, body %v", mediaType, w.Code, w.Header().Get("Content-Type"), got)
+		}
+	}
+
+	w := get("/pokemon", "application/x-protobuf")
+	var msg wrapperspb.StringValue
+	if err := proto.Unmarshal(w.Body.Bytes(), &msg); err != nil || msg.GetValue() != "Pikachu" || w.Header().Get("Content-Type") != Protobuf {
+		t.Errorf("protobuf: %v, value %q, Content-Type %q", err, msg.GetValue(), w.Header().Get("Content-Type"))
+	}
+
+	notAcceptable := []struct{ path, accept string }{
+		{"/pokemon", "text/html"},
+		// Results without a protobuf form are not offered as protobuf.
+		{"/count", "application/x-protobuf"},
+	}
+	for _, tt := range notAcceptable {
+		w := get(tt.path, tt.accept)
+		var problem apierror.Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != http.StatusNotAcceptable || problem.Code != apierror.CodeNotAcceptable || w.Header().Get("Content-Type") != apierror.ContentType {
+			t.Errorf("GET %s as %s: status %d, problem %+v", tt.path, tt.accept, w.Code, problem)
+		}
+	}
+	if w := get("/count", "application/x-protobuf, application/json;q=0.5"); w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("protobuf preferred with a JSON fallback: status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+}
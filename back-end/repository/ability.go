@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AbilityRepository is the read API over the abilities collection, which is
+// shared by every region.
+type AbilityRepository interface {
+	// Abilities returns every ability sorted by name.
+	Abilities(ctx context.Context) ([]models.Ability, error)
+	// GetAbility matches name case-insensitively.
+	GetAbility(ctx context.Context, name string) (models.Ability, error)
+}
+
+// MongoAbilities implements AbilityRepository over a collection.
+type MongoAbilities struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+var _ AbilityRepository = (*MongoAbilities)(nil)
+
+// NewMongoAbilities returns a repository reading from coll.
+func NewMongoAbilities(coll *mongo.Collection) *MongoAbilities {
+	return &MongoAbilities{coll: coll, timeout: DefaultQueryTimeout}
+}
+
+func (m *MongoAbilities) Abilities(ctx context.Context) ([]models.Ability, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	cursor, err := m.coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, classify("list abilities", err)
+	}
+	defer cursor.Close(ctx)
+
+	abilities := []models.Ability{}
+	if err := cursor.All(ctx, &abilities); err != nil {
+		return nil, classify("list abilities", err)
+	}
+	sortAbilities(abilities)
+	return abilities, nil
+}
+
+func (m *MongoAbilities) GetAbility(ctx context.Context, name string) (models.Ability, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Ability{}, fmt.Errorf("get ability: %w", ErrInvalidInput)
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var ability models.Ability
+	if err := m.coll.FindOne(ctx, bson.M{"name": exactMatch(name)}).Decode(&ability); err != nil {
+		return models.Ability{}, classify("get ability", err)
+	}
+	return ability, nil
+}
+
+// MemoryAbilities implements AbilityRepository over a fixed slice.
+type MemoryAbilities struct {
+	abilities []models.Ability
+}
+
+var _ AbilityRepository = (*MemoryAbilities)(nil)
+
+// NewMemoryAbilities returns a repository serving a copy of abilities.
+func NewMemoryAbilities(abilities []models.Ability) *MemoryAbilities {
+	m := &MemoryAbilities{abilities: slices.Clone(abilities)}
+	sortAbilities(m.abilities)
+	return m
+}
+
+func (m *MemoryAbilities) Abilities(ctx context.Context) ([]models.Ability, error) {
+	out := slices.Clone(m.abilities)
+	if out == nil {
+		out = []models.Ability{}
+	}
+	return out, nil
+}
+
+func (m *MemoryAbilities) GetAbility(ctx context.Context, name string) (models.Ability, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Ability{}, fmt.Errorf("get ability: %w", ErrInvalidInput)
+	}
+	for _, a := range m.abilities {
+		if strings.EqualFold(strings.TrimSpace(a.Name), strings.TrimSpace(name)) {
+			return a, nil
+		}
+	}
+	return models.Ability{}, fmt.Errorf("get ability: %w", ErrNotFound)
+}
+
+func sortAbilities(abilities []models.Ability) {
+	slices.SortFunc(abilities, func(a, b models.Ability) int { return strings.Compare(a.Name, b.Name) })
+}
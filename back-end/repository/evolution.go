@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EvolutionRepository is the read API over the evolutions collection, which
+// is shared by every region.
+type EvolutionRepository interface {
+	Evolutions(ctx context.Context) ([]models.Evolution, error)
+}
+
+// MongoEvolutions implements EvolutionRepository over a collection.
+type MongoEvolutions struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+var _ EvolutionRepository = (*MongoEvolutions)(nil)
+
+// NewMongoEvolutions returns a repository reading from coll.
+func NewMongoEvolutions(coll *mongo.Collection) *MongoEvolutions {
+	return &MongoEvolutions{coll: coll, timeout: DefaultQueryTimeout}
+}
+
+func (m *MongoEvolutions) Evolutions(ctx context.Context) ([]models.Evolution, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	cursor, err := m.coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, classify("list evolutions", err)
+	}
+	defer cursor.Close(ctx)
+
+	evolutions := []models.Evolution{}
+	if err := cursor.All(ctx, &evolutions); err != nil {
+		return nil, classify("list evolutions", err)
+	}
+	return evolutions, nil
+}
+
+// MemoryEvolutions implements EvolutionRepository over a fixed slice.
+type MemoryEvolutions struct {
+	evolutions []models.Evolution
+}
+
+var _ EvolutionRepository = (*MemoryEvolutions)(nil)
+
+// NewMemoryEvolutions returns a repository serving a copy of evolutions.
+func NewMemoryEvolutions(evolutions []models.Evolution) *MemoryEvolutions {
+	return &MemoryEvolutions{evolutions: slices.Clone(evolutions)}
+}
+
+func (m *MemoryEvolutions) Evolutions(ctx context.Context) ([]models.Evolution, error) {
+	if m.evolutions == nil {
+		return []models.Evolution{}, nil
+	}
+	return slices.Clone(m.evolutions), nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupKey is one Lookup identifier: a dex number or, failing that, a name.
+type lookupKey struct {
+	number int
+	name   string // lower-cased and trimmed
+}
+
+// parseLookup classifies ids. An empty identifier or a dex number below 1 is
+// invalid input.
+func parseLookup(ids []string) ([]lookupKey, error) {
+	keys := make([]lookupKey, len(ids))
+	for i, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, fmt.Errorf("lookup pokemon: empty identifier: %w", ErrInvalidInput)
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, "#")); err == nil {
+			if n < 1 {
+				return nil, fmt.Errorf("lookup pokemon %q: %w", id, ErrInvalidInput)
+			}
+			keys[i].number = n
+			continue
+		}
+		keys[i].name = strings.ToLower(id)
+	}
+	return keys, nil
+}
+
+// resolveLookup pairs each identifier with its match among found.
+func resolveLookup(ids []string, keys []lookupKey, found []models.Pokemon) LookupResult {
+	byDex := make(map[string]models.Pokemon, len(found))
+	byName := make(map[string]models.Pokemon, len(found))
+	for _, p := range found {
+		byDex[p.DexNumber] = p
+		byName[strings.ToLower(strings.TrimSpace(p.Name))] = p
+	}
+
+	result := LookupResult{Found: []models.Pokemon{}, Missing: []string{}}
+	for i, k := range keys {
+		var p models.Pokemon
+		var ok bool
+		if k.number > 0 {
+			p, ok = byDex[DexNumber(k.number)]
+		} else {
+			p, ok = byName[k.name]
+		}
+		if ok {
+			result.Found = append(result.Found, p)
+		} else {
+			result.Missing = append(result.Missing, ids[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Memory implements PokemonRepository over a fixed slice with the same
+// matching rules as Mongo. It backs tests and tools that run without a
+// database.
+type Memory struct {
+	pokemons []models.Pokemon
+}
+
+var _ PokemonRepository = (*Memory)(nil)
+
+// NewMemory returns a repository serving a copy of pokemons.
+func NewMemory(pokemons []models.Pokemon) *Memory {
+	return &Memory{pokemons: slices.Clone(pokemons)}
+}
+
+func (m *Memory) List(ctx context.Context) ([]models.Pokemon, error) {
+	return m.filter(func(models.Pokemon) bool { return true }), nil
+}
+
+func (m *Memory) GetByDexNumber(ctx context.Context, number int) (models.Pokemon, error) {
+	if number < 1 {
+		return models.Pokemon{}, fmt.Errorf("get pokemon %d: %w", number, ErrInvalidInput)
+	}
+	dex := DexNumber(number)
+	for _, p := range m.pokemons {
+		if p.DexNumber == dex {
+			return p, nil
+		}
+	}
+	return models.Pokemon{}, fmt.Errorf("get pokemon by dex number: %w", ErrNotFound)
+}
+
+func (m *Memory) GetByDexNumbers(ctx context.Context, numbers []int) ([]models.Pokemon, error) {
+	for _, n := range numbers {
+		if n < 1 {
+			return nil, fmt.Errorf("get pokemon %d: %w", n, ErrInvalidInput)
+		}
+	}
+	return inRequestOrder(numbers, m.pokemons), nil
+}
+
+func (m *Memory) GetByName(ctx context.Context, name string) (models.Pokemon, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Pokemon{}, fmt.Errorf("get pokemon by name: %w", ErrInvalidInput)
+	}
+	for _, p := range m.pokemons {
+		if equalFold(p.Name, name) {
+			return p, nil
+		}
+	}
+	return models.Pokemon{}, fmt.Errorf("get pokemon by name: %w", ErrNotFound)
+}
+
+func (m *Memory) Lookup(ctx context.Context, ids []string) (LookupResult, error) {
+	keys, err := parseLookup(ids)
+	if err != nil {
+		return LookupResult{}, err
+	}
+	return resolveLookup(ids, keys, m.pokemons), nil
+}
+
+func (m *Memory) Search(ctx context.Context, filter SearchFilter) ([]models.Pokemon, error) {
+	query := strings.ToLower(filter.Query)
+	return m.filter(func(p models.Pokemon) bool {
+		if query != "" && !strings.Contains(strings.ToLower(p.Name), query) && !strings.Contains(strings.ToLower(p.DexNumber), query) {
+			return false
+		}
+		if filter.Type != "" && !equalFold(p.Type01, filter.Type) && !equalFold(p.Type02, filter.Type) {
+			return false
+		}
+		if filter.Legendary != nil && p.IsLegendary != legendaryValue(*filter.Legendary) {
+			return false
+		}
+		return true
+	}), nil
+}
+
+func (m *Memory) Types(ctx context.Context) ([]string, error) {
+	// Same order as Mongo: distinct type_01 values, then new type_02 values.
+	var first, second []string
+	for _, p := range m.pokemons {
+		first = append(first, strings.TrimSpace(p.Type01))
+		second = append(second, strings.TrimSpace(p.Type02))
+	}
+	slices.Sort(first)
+	slices.Sort(second)
+
+	seen := make(map[string]bool)
+	types := []string{}
+	for _, t := range append(first, second...) {
+		if t != "" && !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+func (m *Memory) Legendary(ctx context.Context) ([]models.Pokemon, error) {
+	return m.filter(func(p models.Pokemon) bool { return p.IsLegendary == legendaryValue(true) }), nil
+}
+
+func (m *Memory) Stats(ctx context.Context) (StatsSummary, error) {
+	summary := StatsSummary{
+		TotalPokemon:     int64(len(m.pokemons)),
+		TypeDistribution: make(map[string]int),
+	}
+	for _, p := range m.pokemons {
+		if p.IsLegendary == legendaryValue(true) {
+			summary.LegendaryCount++
+		}
+		for _, t := range p.Types() {
+			summary.TypeDistribution[t]++
+		}
+	}
+	return summary, nil
+}
+
+// Version always reports an unrecorded version; the data never changes.
+func (m *Memory) Version(ctx context.Context) (DatasetVersion, error) {
+	return DatasetVersion{}, nil
+}
+
+func (m *Memory) filter(keep func(models.Pokemon) bool) []models.Pokemon {
+	out := []models.Pokemon{}
+	for _, p := range m.pokemons {
+		if keep(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// equalFold mirrors exactMatch: case-insensitive, ignoring surrounding space.
+func equalFold(stored, want string) bool {
+	return strings.EqualFold(strings.TrimSpace(stored), strings.TrimSpace(want))
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// DefaultQueryTimeout bounds every query issued by Mongo.
+const DefaultQueryTimeout = 5 * time.Second
+
+// Mongo implements PokemonRepository over a single region collection.
+type Mongo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+var _ PokemonRepository = (*Mongo)(nil)
+
+// NewMongo returns a repository reading from coll.
+func NewMongo(coll *mongo.Collection) *Mongo {
+	return &Mongo{coll: coll, timeout: DefaultQueryTimeout}
+}
+
+func (m *Mongo) List(ctx context.Context) ([]models.Pokemon, error) {
+	return m.find(ctx, "list pokemon", bson.M{})
+}
+
+func (m *Mongo) GetByDexNumber(ctx context.Context, number int) (models.Pokemon, error) {
+	if number < 1 {
+		return models.Pokemon{}, fmt.Errorf("get pokemon %d: %w", number, ErrInvalidInput)
+	}
+	return m.findOne(ctx, "get pokemon by dex number", bson.M{"dex_number": DexNumber(number)})
+}
+
+func (m *Mongo) GetByDexNumbers(ctx context.Context, numbers []int) ([]models.Pokemon, error) {
+	if len(numbers) == 0 {
+		return []models.Pokemon{}, nil
+	}
+	dex := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		if n < 1 {
+			return nil, fmt.Errorf("get pokemon %d: %w", n, ErrInvalidInput)
+		}
+		dex = append(dex, DexNumber(n))
+	}
+	found, err := m.find(ctx, "get pokemon by dex numbers", bson.M{"dex_number": bson.M{"$in": dex}})
+	if err != nil {
+		return nil, err
+	}
+	return inRequestOrder(numbers, found), nil
+}
+
+func (m *Mongo) GetByName(ctx context.Context, name string) (models.Pokemon, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Pokemon{}, fmt.Errorf("get pokemon by name: %w", ErrInvalidInput)
+	}
+	return m.findOne(ctx, "get pokemon by name", bson.M{"name": exactMatch(name)})
+}
+
+func (m *Mongo) Lookup(ctx context.Context, ids []string) (LookupResult, error) {
+	keys, err := parseLookup(ids)
+	if err != nil {
+		return LookupResult{}, err
+	}
+	var dex, names []string
+	for _, k := range keys {
+		if k.number > 0 {
+			dex = append(dex, DexNumber(k.number))
+		} else {
+			names = append(names, regexp.QuoteMeta(k.name))
+		}
+	}
+
+	// One query for both kinds: dex numbers by $in, names by a single
+	// anchored alternation with the same tolerance as exactMatch.
+	var or []bson.M
+	if len(dex) > 0 {
+		or = append(or, bson.M{"dex_number": bson.M{"$in": dex}})
+	}
+	if len(names) > 0 {
+		or = append(or, bson.M{"name": bson.M{"$regex": `^\s*(?:` + strings.Join(names, "|") + `)\s*$`, "$options": "i"}})
+	}
+	var found []models.Pokemon
+	if len(or) > 0 {
+		found, err = m.find(ctx, "lookup pokemon", bson.M{"$or": or})
+		if err != nil {
+			return LookupResult{}, err
+		}
+	}
+	return resolveLookup(ids, keys, found), nil
+}
+
+func (m *Mongo) Search(ctx context.Context, filter SearchFilter) ([]models.Pokemon, error) {
+	var and []bson.M
+
+	// Search by name or dex number
+	if filter.Query != "" {
+		pattern := regexp.QuoteMeta(filter.Query)
+		and = append(and, bson.M{"$or": []bson.M{
+			{"name": bson.M{"$regex": pattern, "$options": "i"}},
+			{"dex_number": bson.M{"$regex": pattern, "$options": "i"}},
+		}})
+	}
+
+	// Filter by type
+	if filter.Type != "" {
+		and = append(and, bson.M{"$or": []bson.M{
+			{"type_01": exactMatch(filter.Type)},
+			{"type_02": exactMatch(filter.Type)},
+		}})
+	}
+
+	// Filter by legendary status
+	if filter.Legendary != nil {
+		and = append(and, bson.M{"is_legendary": legendaryValue(*filter.Legendary)})
+	}
+
+	query := bson.M{}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+	return m.find(ctx, "search pokemon", query)
+}
+
+func (m *Mongo) Types(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	// Get distinct types from both type_01 and type_02 fields
+	types1, err := m.coll.Distinct(ctx, "type_01", bson.M{})
+	if err != nil {
+		return nil, classify("distinct type_01", err)
+	}
+	types2, err := m.coll.Distinct(ctx, "type_02", bson.M{"type_02": bson.M{"$ne": ""}})
+	if err != nil {
+		return nil, classify("distinct type_02", err)
+	}
+
+	// Combine and deduplicate types; type_02 is stored with a leading space
+	typeSet := make(map[string]bool)
+	allTypes := []string{}
+	for _, t := range append(types1, types2...) {
+		typeStr, ok := t.(string)
+		typeStr = strings.TrimSpace(typeStr)
+		if ok && typeStr != "" && !typeSet[typeStr] {
+			typeSet[typeStr] = true
+			allTypes = append(allTypes, typeStr)
+		}
+	}
+	return allTypes, nil
+}
+
+func (m *Mongo) Legendary(ctx context.Context) ([]models.Pokemon, error) {
+	return m.find(ctx, "list legendary pokemon", bson.M{"is_legendary": legendaryValue(true)})
+}
+
+func (m *Mongo) Stats(ctx context.Context) (StatsSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	totalCount, err := m.coll.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return StatsSummary{}, classify("count pokemon", err)
+	}
+	legendaryCount, err := m.coll.CountDocuments(ctx, bson.M{"is_legendary": legendaryValue(true)})
+	if err != nil {
+		return StatsSummary{}, classify("count legendary pokemon", err)
+	}
+
+	// Type distribution over both type slots, so a dual-type Pokemon counts
+	// once for each of its types. type_02 carries stray whitespace.
+	pipeline := []bson.M{
+		{"$project": bson.M{"type": bson.A{"$type_01", "$type_02"}}},
+		{"$unwind": "$type"},
+		{"$project": bson.M{"type": bson.M{"$trim": bson.M{"input": "$type"}}}},
+		{"$match": bson.M{"type": bson.M{"$nin": bson.A{"", nil}}}},
+		{
+			"$group": bson.M{
+				"_id":   "$type",
+				"count": bson.M{"$sum": 1},
+			},
+		},
+	}
+	cursor, err := m.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return StatsSummary{}, classify("aggregate type distribution", err)
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []struct {
+		ID    string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return StatsSummary{}, classify("decode type distribution", err)
+	}
+	typeDistribution := make(map[string]int, len(buckets))
+	for _, b := range buckets {
+		typeDistribution[b.ID] = b.Count
+	}
+
+	return StatsSummary{
+		TotalPokemon:     totalCount,
+		LegendaryCount:   legendaryCount,
+		TypeDistribution: typeDistribution,
+	}, nil
+}
+
+func (m *Mongo) find(ctx context.Context, op string, filter bson.M) ([]models.Pokemon, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	cursor, err := m.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, classify(op, err)
+	}
+	defer cursor.Close(ctx)
+
+	pokemons := []models.Pokemon{}
+	if err := cursor.All(ctx, &pokemons); err != nil {
+		return nil, classify(op, err)
+	}
+	return pokemons, nil
+}
+
+func (m *Mongo) findOne(ctx context.Context, op string, filter bson.M) (models.Pokemon, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var pokemon models.Pokemon
+	if err := m.coll.FindOne(ctx, filter).Decode(&pokemon); err != nil {
+		return models.Pokemon{}, classify(op, err)
+	}
+	return pokemon, nil
+}
+
+// DexNumber formats n the way the datasets store it, e.g. 1 -> "#0001".
+func DexNumber(n int) string {
+	return fmt.Sprintf("#%04d", n)
+}
+
+// inRequestOrder arranges found by numbers, repeating duplicates and
+// skipping numbers that were not found.
+func inRequestOrder(numbers []int, found []models.Pokemon) []models.Pokemon {
+	byDex := make(map[string]models.Pokemon, len(found))
+	for _, p := range found {
+		byDex[p.DexNumber] = p
+	}
+	out := make([]models.Pokemon, 0, len(numbers))
+	for _, n := range numbers {
+		if p, ok := byDex[DexNumber(n)]; ok {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// exactMatch is a case-insensitive equality match tolerant of the stray
+// whitespace found in the imported datasets.
+func exactMatch(s string) bson.M {
+	return bson.M{"$regex": `^\s*` + regexp.QuoteMeta(strings.TrimSpace(s)) + `\s*$`, "$options": "i"}
+}
+
+func legendaryValue(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
+
+// classify maps driver errors onto the package's error kinds, keeping the
+// original error in the chain.
+func classify(op string, err error) error {
+	var selErr topology.ServerSelectionError
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return fmt.Errorf("%s: %w", op, ErrNotFound)
+	case errors.As(err, &selErr), mongo.IsNetworkError(err), errors.Is(err, mongo.ErrClientDisconnected):
+		return fmt.Errorf("%s: %w: %w", op, ErrUnavailable, err)
+	case mongo.IsTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%s: %w: %w", op, ErrTimeout, err)
+	default:
+		return fmt.Errorf("%s: %w", op, err)
+	}
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MoveRepository is the read API over the moves and learnsets collections,
+// which are shared by every region.
+type MoveRepository interface {
+	// Moves returns every move sorted by name.
+	Moves(ctx context.Context) ([]models.Move, error)
+	// GetMove matches name case-insensitively.
+	GetMove(ctx context.Context, name string) (models.Move, error)
+	// Learnsets returns the learnsets of one Pokemon, one per game version.
+	Learnsets(ctx context.Context, number int) ([]models.Learnset, error)
+	// Learners returns the learnsets that contain move, matched
+	// case-insensitively.
+	Learners(ctx context.Context, move string) ([]models.Learnset, error)
+}
+
+// MongoMoves implements MoveRepository over the two collections.
+type MongoMoves struct {
+	moves     *mongo.Collection
+	learnsets *mongo.Collection
+	timeout   time.Duration
+}
+
+var _ MoveRepository = (*MongoMoves)(nil)
+
+// NewMongoMoves returns a repository reading moves and learnsets.
+func NewMongoMoves(moves, learnsets *mongo.Collection) *MongoMoves {
+	return &MongoMoves{moves: moves, learnsets: learnsets, timeout: DefaultQueryTimeout}
+}
+
+func (m *MongoMoves) Moves(ctx context.Context) ([]models.Move, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	cursor, err := m.moves.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, classify("list moves", err)
+	}
+	defer cursor.Close(ctx)
+
+	moves := []models.Move{}
+	if err := cursor.All(ctx, &moves); err != nil {
+		return nil, classify("list moves", err)
+	}
+	sortMoves(moves)
+	return moves, nil
+}
+
+func (m *MongoMoves) GetMove(ctx context.Context, name string) (models.Move, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Move{}, fmt.Errorf("get move: %w", ErrInvalidInput)
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var move models.Move
+	if err := m.moves.FindOne(ctx, bson.M{"name": exactMatch(name)}).Decode(&move); err != nil {
+		return models.Move{}, classify("get move", err)
+	}
+	return move, nil
+}
+
+func (m *MongoMoves) Learnsets(ctx context.Context, number int) ([]models.Learnset, error) {
+	if number < 1 {
+		return nil, fmt.Errorf("get learnsets %d: %w", number, ErrInvalidInput)
+	}
+	return m.findLearnsets(ctx, "get learnsets", bson.M{"dex_number": DexNumber(number)})
+}
+
+func (m *MongoMoves) Learners(ctx context.Context, move string) ([]models.Learnset, error) {
+	if strings.TrimSpace(move) == "" {
+		return nil, fmt.Errorf("get learners: %w", ErrInvalidInput)
+	}
+	return m.findLearnsets(ctx, "get learners", bson.M{"moves.move": exactMatch(move)})
+}
+
+func (m *MongoMoves) findLearnsets(ctx context.Context, op string, filter bson.M) ([]models.Learnset, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	cursor, err := m.learnsets.Find(ctx, filter)
+	if err != nil {
+		return nil, classify(op, err)
+	}
+	defer cursor.Close(ctx)
+
+	learnsets := []models.Learnset{}
+	if err := cursor.All(ctx, &learnsets); err != nil {
+		return nil, classify(op, err)
+	}
+	sortLearnsets(learnsets)
+	return learnsets, nil
+}
+
+// MemoryMoves implements MoveRepository over fixed slices.
+type MemoryMoves struct {
+	moves     []models.Move
+	learnsets []models.Learnset
+}
+
+var _ MoveRepository = (*MemoryMoves)(nil)
+
+// NewMemoryMoves returns a repository serving copies of moves and learnsets.
+func NewMemoryMoves(moves []models.Move, learnsets []models.Learnset) *MemoryMoves {
+	m := &MemoryMoves{moves: slices.Clone(moves), learnsets: slices.Clone(learnsets)}
+	sortMoves(m.moves)
+	sortLearnsets(m.learnsets)
+	return m
+}
+
+func (m *MemoryMoves) Moves(ctx context.Context) ([]models.Move, error) {
+	out := slices.Clone(m.moves)
+	if out == nil {
+		out = []models.Move{}
+	}
+	return out, nil
+}
+
+func (m *MemoryMoves) GetMove(ctx context.Context, name string) (models.Move, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Move{}, fmt.Errorf("get move: %w", ErrInvalidInput)
+	}
+	for _, move := range m.moves {
+		if strings.EqualFold(strings.TrimSpace(move.Name), strings.TrimSpace(name)) {
+			return move, nil
+		}
+	}
+	return models.Move{}, fmt.Errorf("get move: %w", ErrNotFound)
+}
+
+func (m *MemoryMoves) Learnsets(ctx context.Context, number int) ([]models.Learnset, error) {
+	if number < 1 {
+		return nil, fmt.Errorf("get learnsets %d: %w", number, ErrInvalidInput)
+	}
+	dex := DexNumber(number)
+	return m.filterLearnsets(func(l models.Learnset) bool { return l.DexNumber == dex }), nil
+}
+
+func (m *MemoryMoves) Learners(ctx context.Context, move string) ([]models.Learnset, error) {
+	if strings.TrimSpace(move) == "" {
+		return nil, fmt.Errorf("get learners: %w", ErrInvalidInput)
+	}
+	return m.filterLearnsets(func(l models.Learnset) bool {
+		return slices.ContainsFunc(l.Moves, func(lm models.LearnedMove) bool {
+			return strings.EqualFold(strings.TrimSpace(lm.Move), strings.TrimSpace(move))
+		})
+	}), nil
+}
+
+func (m *MemoryMoves) filterLearnsets(keep func(models.Learnset) bool) []models.Learnset {
+	out := []models.Learnset{}
+	for _, l := range m.learnsets {
+		if keep(l) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func sortMoves(moves []models.Move) {
+	slices.SortFunc(moves, func(a, b models.Move) int { return strings.Compare(a.Name, b.Name) })
+}
+
+// sortLearnsets orders learnsets by dex number, then version.
+func sortLearnsets(learnsets []models.Learnset) {
+	slices.SortFunc(learnsets, func(a, b models.Learnset) int {
+		if c := strings.Compare(a.DexNumber, b.DexNumber); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Version, b.Version)
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func testMoves() *MemoryMoves {
+	power := 40
+	return NewMemoryMoves(
+		[]models.Move{
+			{Name: "Tackle", Type: "Normal", Category: models.CategoryPhysical, Power: &power, PP: 35},
+			{Name: "Growl", Type: "Normal", Category: models.CategoryStatus, PP: 40},
+			{Name: "Charm", Type: "Fairy", Category: models.CategoryStatus, PP: 20},
+		},
+		[]models.Learnset{
+			{DexNumber: "#0025", Version: "gold-silver", Moves: []models.LearnedMove{
+				{Move: "Growl", Method: models.LearnLevelUp, Level: 1},
+				{Move: "Charm", Method: models.LearnEgg},
+			}},
+			{DexNumber: "#0001", Version: "red-blue", Moves: []models.LearnedMove{{Move: "tackle", Method: models.LearnLevelUp, Level: 1}}},
+			{DexNumber: "#0025", Version: "crystal", Moves: []models.LearnedMove{{Move: "Growl", Method: models.LearnLevelUp, Level: 1}}},
+			{DexNumber: "#0001", Version: "gold-silver", Moves: []models.LearnedMove{{Move: "Tackle", Method: models.LearnLevelUp, Level: 1}}},
+		},
+	)
+}
+
+func TestMemoryMoves(t *testing.T) {
+	ctx := context.Background()
+	repo := testMoves()
+
+	moves, err := repo.Moves(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, m := range moves {
+		names = append(names, m.Name)
+	}
+	if !slices.Equal(names, []string{"Charm", "Growl", "Tackle"}) {
+		t.Errorf("Moves = %v, want sorted by name", names)
+	}
+	// The repository hands out copies.
+	moves[0].Name = "Changed"
+	if again, _ := repo.Moves(ctx); again[0].Name != "Charm" {
+		t.Errorf("Moves shares its slice with the caller")
+	}
+
+	if m, err := repo.GetMove(ctx, " TACKLE "); err != nil || m.Name != "Tackle" {
+		t.Errorf("GetMove(TACKLE) = %+v, %v, want Tackle", m, err)
+	}
+	if _, err := repo.GetMove(ctx, "Surf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetMove(Surf) error = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.GetMove(ctx, " "); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetMove(blank) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestMemoryLearnsets(t *testing.T) {
+	ctx := context.Background()
+	repo := testMoves()
+	key := func(ls []models.Learnset) []string {
+		var out []string
+		for _, l := range ls {
+			out = append(out, l.DexNumber+" "+l.Version)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		get  func() ([]models.Learnset, error)
+		want []string
+	}{
+		{"learnsets of one Pokemon", func() ([]models.Learnset, error) { return repo.Learnsets(ctx, 25) }, []string{"#0025 crystal", "#0025 gold-silver"}},
+		{"no learnsets", func() ([]models.Learnset, error) { return repo.Learnsets(ctx, 152) }, nil},
+		{"learners ignore case", func() ([]models.Learnset, error) { return repo.Learners(ctx, "TACKLE") }, []string{"#0001 gold-silver", "#0001 red-blue"}},
+		{"egg move learners", func() ([]models.Learnset, error) { return repo.Learners(ctx, "charm") }, []string{"#0025 gold-silver"}},
+	}
+	for _, tt := range tests {
+		got, err := tt.get()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got == nil || !slices.Equal(key(got), tt.want) {
+			t.Errorf("%s = %v, want %v (never nil)", tt.name, key(got), tt.want)
+		}
+	}
+
+	if _, err := repo.Learnsets(ctx, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Learnsets(0) error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := repo.Learners(ctx, ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Learners(\"\") error = %v, want ErrInvalidInput", err)
+	}
+}
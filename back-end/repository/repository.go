@@ -0,0 +1,64 @@
+// Package repository is the data access layer for Pokemon documents. Handlers
+// depend on the PokemonRepository interface rather than on the Mongo driver.
+package repository
+
+import (
+	"GO-Mongo/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// Error kinds returned by every repository implementation. Callers match them
+// with errors.Is; the underlying driver error stays in the chain for logging.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrTimeout      = errors.New("timeout")
+	ErrUnavailable  = errors.New("unavailable")
+)
+
+// SearchFilter narrows Search results. Zero values mean "no constraint".
+type SearchFilter struct {
+	Query     string // substring of the name or dex number
+	Type      string // matches either type slot
+	Legendary *bool
+}
+
+// StatsSummary is the aggregate returned by Stats.
+type StatsSummary struct {
+	TotalPokemon     int64          `json:"totalPokemon"`
+	LegendaryCount   int64          `json:"legendaryCount"`
+	TypeDistribution map[string]int `json:"typeDistribution"` // counts both types of dual-type Pokemon
+}
+
+// LookupResult is the outcome of Lookup.
+type LookupResult struct {
+	Found   []models.Pokemon // matches in request order, repeated for duplicate identifiers
+	Missing []string         // identifiers without a match, in request order
+}
+
+// DatasetVersion identifies the state of the imported data. The importer bumps
+// it after every successful import; a zero Version means it was never recorded.
+type DatasetVersion struct {
+	Version   int64     `json:"version" bson:"version"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// PokemonRepository is the read API over the Pokemon dataset.
+type PokemonRepository interface {
+	List(ctx context.Context) ([]models.Pokemon, error)
+	GetByDexNumber(ctx context.Context, number int) (models.Pokemon, error)
+	// GetByDexNumbers returns the Pokemon found among numbers in the order
+	// requested; numbers without a match are skipped.
+	GetByDexNumbers(ctx context.Context, numbers []int) ([]models.Pokemon, error)
+	GetByName(ctx context.Context, name string) (models.Pokemon, error)
+	// Lookup resolves identifiers that are each a dex number ("25" or
+	// "#0025") or a case-insensitive name, with a single query.
+	Lookup(ctx context.Context, ids []string) (LookupResult, error)
+	Search(ctx context.Context, filter SearchFilter) ([]models.Pokemon, error)
+	Types(ctx context.Context) ([]string, error)
+	Legendary(ctx context.Context) ([]models.Pokemon, error)
+	Stats(ctx context.Context) (StatsSummary, error)
+	Version(ctx context.Context) (DatasetVersion, error)
+}
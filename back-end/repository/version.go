@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MetaCollection holds one document per dataset recording its version.
+const MetaCollection = "dataset_meta"
+
+const pokemonDatasetID = "pokemon"
+
+func (m *Mongo) Version(ctx context.Context) (DatasetVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var v DatasetVersion
+	err := m.coll.Database().Collection(MetaCollection).FindOne(ctx, bson.M{"_id": pokemonDatasetID}).Decode(&v)
+	if err == mongo.ErrNoDocuments {
+		return DatasetVersion{}, nil
+	}
+	if err != nil {
+		return DatasetVersion{}, classify("read dataset version", err)
+	}
+	return v, nil
+}
+
+// BumpVersion increments the dataset version in database. Anything that
+// changes Pokemon documents must call it so API caches are invalidated.
+func BumpVersion(ctx context.Context, database *mongo.Database) (DatasetVersion, error) {
+	var v DatasetVersion
+	err := database.Collection(MetaCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": pokemonDatasetID},
+		bson.M{
+			"$inc": bson.M{"version": 1},
+			"$set": bson.M{"updated_at": time.Now().UTC().Truncate(time.Second)},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&v)
+	if err != nil {
+		return DatasetVersion{}, classify("bump dataset version", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,291 @@
+// Package similar finds the Pokemon closest to a given one by base stats,
+// optionally favouring those that share types, abilities or egg groups.
+//
+// Each of the six base stats is scaled to 0-1 over the indexed Pokemon, so
+// no stat dominates the distance because of its range, and distances are
+// scaled to 0-1 as well so the weights of shared traits compare to them.
+package similar
+
+import (
+	"GO-Mongo/models"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Metric is a distance between two stat vectors.
+type Metric string
+
+// Supported metrics.
+const (
+	Euclidean Metric = "euclidean"
+	Manhattan Metric = "manhattan"
+	Cosine    Metric = "cosine" // 1 minus the cosine similarity: compares stat shape, not size
+)
+
+// Metrics lists the supported metrics, the default first.
+var Metrics = []Metric{Euclidean, Manhattan, Cosine}
+
+var (
+	ErrNotFound      = errors.New("pokemon not indexed")
+	ErrUnknownMetric = errors.New("unknown metric")
+)
+
+const dimensions = 6
+
+// Region is a named set of Pokemon to index.
+type Region struct {
+	Name     string
+	Pokemons []models.Pokemon
+}
+
+// Weights lower the score of a neighbour by weight times the share of the
+// traits it has in common (0-1), so a weight of 1 on types outweighs any
+// stat difference for a Pokemon with the same types. Zero weights compare
+// stats only.
+type Weights struct {
+	Types     float64
+	Abilities float64
+	EggGroups float64
+}
+
+// Query selects the neighbours returned by Nearest.
+type Query struct {
+	Metric  Metric
+	Weights Weights
+	Limit   int
+}
+
+// Shared lists the traits a neighbour has in common with the queried Pokemon.
+type Shared struct {
+	Types     []string `json:"types"`
+	Abilities []string `json:"abilities" doc:"Regular and hidden abilities"`
+	EggGroups []string `json:"egg_groups"`
+}
+
+// Neighbor is a Pokemon close to the queried one.
+type Neighbor struct {
+	DexNumber string           `json:"dex_number"`
+	Name      string           `json:"name"`
+	Region    string           `json:"region"`
+	Types     []string         `json:"types"`
+	BaseStats models.BaseStats `json:"base_stats"`
+	Distance  float64          `json:"distance" doc:"Stat distance, 0-1"`
+	Score     float64          `json:"score" doc:"Distance minus the weighted shared traits; lower is closer"`
+	Shared    Shared           `json:"shared"`
+}
+
+type entry struct {
+	pokemon   models.Pokemon
+	region    string
+	vector    [dimensions]float64
+	types     []string
+	abilities []string
+	eggGroups []string
+}
+
+// Index holds the normalized stats of a fixed set of Pokemon. It is safe for
+// concurrent use; build a new one when the data changes.
+type Index struct {
+	entries []entry
+	byDex   map[string]int
+}
+
+// Build indexes the Pokemon of regions. A dex number present in several
+// regions is indexed once, from the first.
+func Build(regions []Region) *Index {
+	ix := &Index{byDex: map[string]int{}}
+	var lo, hi [dimensions]float64
+	for i := range lo {
+		lo[i], hi[i] = math.Inf(1), math.Inf(-1)
+	}
+	for _, r := range regions {
+		for _, p := range r.Pokemons {
+			dex := strings.TrimSpace(p.DexNumber)
+			if _, ok := ix.byDex[dex]; ok {
+				continue
+			}
+			e := entry{
+				pokemon:   p,
+				region:    r.Name,
+				vector:    raw(p.BaseStats()),
+				types:     p.Types(),
+				abilities: append(p.Abilities(), nonEmpty(p.HiddenAbility)...),
+				eggGroups: p.EggGroups(),
+			}
+			for i, v := range e.vector {
+				lo[i], hi[i] = min(lo[i], v), max(hi[i], v)
+			}
+			ix.byDex[dex] = len(ix.entries)
+			ix.entries = append(ix.entries, e)
+		}
+	}
+	for n := range ix.entries {
+		for i, v := range ix.entries[n].vector {
+			if hi[i] > lo[i] {
+				ix.entries[n].vector[i] = (v - lo[i]) / (hi[i] - lo[i])
+			} else {
+				ix.entries[n].vector[i] = 0
+			}
+		}
+	}
+	return ix
+}
+
+// Len is the number of indexed Pokemon.
+func (ix *Index) Len() int { return len(ix.entries) }
+
+// Name returns the name of the Pokemon with dexNumber, or "" when it is not
+// indexed.
+func (ix *Index) Name(dexNumber string) string {
+	if n, ok := ix.byDex[strings.TrimSpace(dexNumber)]; ok {
+		return ix.entries[n].pokemon.Name
+	}
+	return ""
+}
+
+// Nearest returns up to q.Limit Pokemon closest to the one with dexNumber
+// (e.g. "#0025"), closest first, itself excluded. An empty metric is
+// Euclidean.
+func (ix *Index) Nearest(dexNumber string, q Query) ([]Neighbor, error) {
+	n, ok := ix.byDex[strings.TrimSpace(dexNumber)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", dexNumber, ErrNotFound)
+	}
+	if q.Metric == "" {
+		q.Metric = Euclidean
+	}
+	distance, err := metric(q.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	target := ix.entries[n]
+	out := make([]Neighbor, 0, len(ix.entries)-1)
+	for i, e := range ix.entries {
+		if i == n {
+			continue
+		}
+		shared := Shared{
+			Types:     common(target.types, e.types),
+			Abilities: common(target.abilities, e.abilities),
+			EggGroups: common(target.eggGroups, e.eggGroups),
+		}
+		d := distance(target.vector, e.vector)
+		score := d -
+			q.Weights.Types*share(shared.Types, target.types, e.types) -
+			q.Weights.Abilities*share(shared.Abilities, target.abilities, e.abilities) -
+			q.Weights.EggGroups*share(shared.EggGroups, target.eggGroups, e.eggGroups)
+		out = append(out, Neighbor{
+			DexNumber: e.pokemon.DexNumber,
+			Name:      e.pokemon.Name,
+			Region:    e.region,
+			Types:     e.types,
+			BaseStats: e.pokemon.BaseStats(),
+			Distance:  round(d),
+			Score:     round(score),
+			Shared:    shared,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b Neighbor) int {
+		if a.Score != b.Score {
+			if a.Score < b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.DexNumber, b.DexNumber)
+	})
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+// ParseMetric finds a metric by name, ignoring case.
+func ParseMetric(name string) (Metric, error) {
+	for _, m := range Metrics {
+		if strings.EqualFold(string(m), strings.TrimSpace(name)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("%q: %w", name, ErrUnknownMetric)
+}
+
+// metric returns the distance function of m, scaled to 0-1 for vectors with
+// components in 0-1.
+func metric(m Metric) (func(a, b [dimensions]float64) float64, error) {
+	switch m {
+	case Euclidean:
+		return func(a, b [dimensions]float64) float64 {
+			var sum float64
+			for i := range a {
+				sum += (a[i] - b[i]) * (a[i] - b[i])
+			}
+			return math.Sqrt(sum / dimensions)
+		}, nil
+	case Manhattan:
+		return func(a, b [dimensions]float64) float64 {
+			var sum float64
+			for i := range a {
+				sum += math.Abs(a[i] - b[i])
+			}
+			return sum / dimensions
+		}, nil
+	case Cosine:
+		return func(a, b [dimensions]float64) float64 {
+			var dot, na, nb float64
+			for i := range a {
+				dot += a[i] * b[i]
+				na += a[i] * a[i]
+				nb += b[i] * b[i]
+			}
+			if na == 0 || nb == 0 {
+				return 1
+			}
+			return 1 - dot/math.Sqrt(na*nb)
+		}, nil
+	}
+	return nil, fmt.Errorf("%q: %w", m, ErrUnknownMetric)
+}
+
+func raw(b models.BaseStats) [dimensions]float64 {
+	return [dimensions]float64{float64(b.HP), float64(b.Attack), float64(b.Defense), float64(b.SpAttack), float64(b.SpDefense), float64(b.Speed)}
+}
+
+// common returns the values of a also in b, ignoring case, in a's order.
+func common(a, b []string) []string {
+	out := []string{}
+	for _, v := range a {
+		if slices.ContainsFunc(b, func(w string) bool { return strings.EqualFold(v, w) }) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// share is the Jaccard index of a and b given their common values.
+func share(shared, a, b []string) float64 {
+	union := len(a) + len(b) - len(shared)
+	if union == 0 {
+		return 0
+	}
+	return float64(len(shared)) / float64(union)
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// round keeps four decimals.
+func round(f float64) float64 {
+	return math.Round(f*10000) / 10000
+}
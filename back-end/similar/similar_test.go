@@ -0,0 +1,95 @@
+package similar
+
+import (
+	"GO-Mongo/models"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func pokemon(dex, name, t1 string, hp, speed int) models.Pokemon {
+	// Attack to Sp. Def are the same for every fixture, so only HP and Speed
+	// set the distance; each stat still has its own value.
+	return models.Pokemon{
+		DexNumber: dex, Name: name, Type01: t1,
+		HP: strconv.Itoa(hp), Attack: "45", Defense: "55", SpAttack: "65", SpDefense: "75", Speed: strconv.Itoa(speed),
+	}
+}
+
+func TestNearest(t *testing.T) {
+	// HP spans 10-250 and Speed 40-60, so after scaling a Speed gap of 10
+	// is farther than an HP gap of 40: B is closer to A than C is, although
+	// C is closer in raw points.
+	ix := Build([]Region{
+		{Name: "kanto", Pokemons: []models.Pokemon{
+			pokemon("#0001", "A", "Grass", 100, 50),
+			pokemon("#0002", "B", "Fire", 140, 50),
+			pokemon("#0003", "C", "Water", 100, 60),
+			pokemon("#0004", "D", "Water", 250, 40),
+		}},
+		{Name: "johto", Pokemons: []models.Pokemon{
+			pokemon("#0004", "D again", "Water", 100, 50),
+			pokemon("#0152", "E", "Grass", 10, 60),
+		}},
+	})
+	if ix.Len() != 5 {
+		t.Fatalf("Len = %d, want 5 (duplicate dex number indexed once)", ix.Len())
+	}
+
+	tests := []struct {
+		name    string
+		weights Weights
+		want    []string
+	}{
+		{"stats only", Weights{}, []string{"B", "C", "E", "D"}},
+		{"shared type", Weights{Types: 1}, []string{"E", "B", "C", "D"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ix.Nearest("#0001", Query{Weights: tt.weights})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, n := range got {
+				names = append(names, n.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Fatalf("Nearest = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	// B differs by 40/240 of the HP range in one of six stats.
+	got, _ := ix.Nearest("#0001", Query{})
+	if want := 0.068; got[0].Distance != want {
+		t.Errorf("distance to B = %g, want %g", got[0].Distance, want)
+	}
+	if got, _ := ix.Nearest("#0001", Query{Limit: 2}); len(got) != 2 {
+		t.Errorf("%d neighbours, want the limit of 2", len(got))
+	}
+	if _, err := ix.Nearest("#0999", Query{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+	if _, err := ix.Nearest("#0001", Query{Metric: "chebyshev"}); !errors.Is(err, ErrUnknownMetric) {
+		t.Errorf("err = %v, want ErrUnknownMetric", err)
+	}
+}
+
+func TestMetricsScaled(t *testing.T) {
+	var zero, one [dimensions]float64
+	for i := range one {
+		one[i] = 1
+	}
+	for _, m := range []Metric{Euclidean, Manhattan} {
+		d, _ := metric(m)
+		if got := d(zero, one); got != 1 {
+			t.Errorf("%s between opposite corners = %g, want 1", m, got)
+		}
+	}
+	d, _ := metric(Cosine)
+	if got := d(one, one); got != 0 {
+		t.Errorf("cosine of equal vectors = %g, want 0", got)
+	}
+}
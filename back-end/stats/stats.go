@@ -0,0 +1,304 @@
+// Package stats computes the actual stats of a Pokemon from its base stats,
+// level, individual values, effort values and nature, with the formula of
+// the requested game generation.
+//
+// Generations 1 and 2 use DVs (0-15) and stat experience (0-65535) and have
+// no natures; the HP DV is derived from the other DVs and Sp. Atk and Sp. Def
+// share the Special DV. Generation 3 onwards use IVs (0-31), EVs (at most 510
+// in total, 255 per stat before generation 6 and 252 since) and natures.
+package stats
+
+import (
+	"GO-Mongo/models"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Generations covered by the formulas.
+const (
+	FirstGeneration  = 1
+	LatestGeneration = 9
+	// ModernGeneration is the first generation with IVs, EVs and natures.
+	ModernGeneration = 3
+)
+
+// Limits of the individual and effort values.
+const (
+	MaxDV         = 15
+	MaxStatExp    = 65535
+	MaxIV         = 31
+	MaxEVTotal    = 510
+	maxEVGen3     = 255
+	maxEVModern   = 252
+	firstGen252EV = 6
+)
+
+var ErrInvalidInput = errors.New("invalid stats input")
+
+// Stat is one of the six stats.
+type Stat int
+
+const (
+	HP Stat = iota
+	Attack
+	Defense
+	SpAttack
+	SpDefense
+	Speed
+)
+
+// All lists the stats in the games' order.
+var All = []Stat{HP, Attack, Defense, SpAttack, SpDefense, Speed}
+
+var statNames = [...]string{"hp", "attack", "defense", "sp_attack", "sp_defense", "speed"}
+
+// String returns the stat's JSON field name, e.g. sp_attack.
+func (s Stat) String() string { return statNames[s] }
+
+// Values holds one number per stat: base stats, IVs, EVs or actual stats.
+type Values struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"sp_attack"`
+	SpDefense int `json:"sp_defense"`
+	Speed     int `json:"speed"`
+}
+
+// Uniform returns Values with every stat set to n.
+func Uniform(n int) Values {
+	return Values{n, n, n, n, n, n}
+}
+
+// FromBase converts a Pokemon's parsed base stats.
+func FromBase(b models.BaseStats) Values {
+	return Values{b.HP, b.Attack, b.Defense, b.SpAttack, b.SpDefense, b.Speed}
+}
+
+// Get returns the value of s.
+func (v Values) Get(s Stat) int {
+	return *v.field(s)
+}
+
+// Set changes the value of s.
+func (v *Values) Set(s Stat, n int) {
+	*v.field(s) = n
+}
+
+// Total is the sum of the six values.
+func (v Values) Total() int {
+	return v.HP + v.Attack + v.Defense + v.SpAttack + v.SpDefense + v.Speed
+}
+
+func (v *Values) field(s Stat) *int {
+	switch s {
+	case HP:
+		return &v.HP
+	case Attack:
+		return &v.Attack
+	case Defense:
+		return &v.Defense
+	case SpAttack:
+		return &v.SpAttack
+	case SpDefense:
+		return &v.SpDefense
+	default:
+		return &v.Speed
+	}
+}
+
+// Nature raises one stat by 10% and lowers another by 10%; the five neutral
+// natures raise and lower the same stat, which cancels out.
+type Nature struct {
+	Name string `json:"name"`
+	Up   Stat   `json:"-"`
+	Down Stat   `json:"-"`
+}
+
+// Natures lists the 25 natures in the games' order.
+var Natures = []Nature{
+	{"Hardy", Attack, Attack}, {"Lonely", Attack, Defense}, {"Brave", Attack, Speed}, {"Adamant", Attack, SpAttack}, {"Naughty", Attack, SpDefense},
+	{"Bold", Defense, Attack}, {"Docile", Defense, Defense}, {"Relaxed", Defense, Speed}, {"Impish", Defense, SpAttack}, {"Lax", Defense, SpDefense},
+	{"Timid", Speed, Attack}, {"Hasty", Speed, Defense}, {"Serious", Speed, Speed}, {"Jolly", Speed, SpAttack}, {"Naive", Speed, SpDefense},
+	{"Modest", SpAttack, Attack}, {"Mild", SpAttack, Defense}, {"Quiet", SpAttack, Speed}, {"Bashful", SpAttack, SpAttack}, {"Rash", SpAttack, SpDefense},
+	{"Calm", SpDefense, Attack}, {"Gentle", SpDefense, Defense}, {"Sassy", SpDefense, Speed}, {"Careful", SpDefense, SpAttack}, {"Quirky", SpDefense, SpDefense},
+}
+
+// Neutral is the nature used when none is given.
+var Neutral = Natures[0]
+
+// ParseNature finds a nature by name, ignoring case.
+func ParseNature(name string) (Nature, error) {
+	for _, n := range Natures {
+		if strings.EqualFold(n.Name, strings.TrimSpace(name)) {
+			return n, nil
+		}
+	}
+	return Nature{}, fmt.Errorf("nature %q: %w", name, ErrInvalidInput)
+}
+
+// Neutral reports whether the nature changes no stat.
+func (n Nature) Neutral() bool { return n.Up == n.Down }
+
+// apply multiplies a stat by the nature, rounding down as the games do.
+func (n Nature) apply(s Stat, value int) int {
+	switch {
+	case n.Neutral():
+		return value
+	case s == n.Up:
+		return value * 110 / 100
+	case s == n.Down:
+		return value * 90 / 100
+	}
+	return value
+}
+
+// Input is everything the formula depends on. In generations 1 and 2, IVs are
+// DVs and EVs are stat experience; Nature must be neutral (or zero).
+type Input struct {
+	Generation int
+	Level      int
+	Base       Values
+	IVs        Values
+	EVs        Values
+	Nature     Nature
+}
+
+// Calculate returns the actual stats of in.
+func Calculate(in Input) (Values, error) {
+	if in.Nature.Name == "" {
+		in.Nature = Neutral
+	}
+	if err := in.validate(); err != nil {
+		return Values{}, err
+	}
+
+	var out Values
+	if in.Generation < ModernGeneration {
+		dvs := DVs(in.IVs)
+		for _, s := range All {
+			// floor((((base + DV) * 2 + floor(ceil(sqrt(statExp)) / 4)) * level) / 100)
+			exp := int(math.Ceil(math.Sqrt(float64(in.EVs.Get(s))))) / 4
+			out.Set(s, finish(s, ((in.Base.Get(s)+dvs.Get(s))*2+exp)*in.Level/100, in.Level))
+		}
+		return out, nil
+	}
+	for _, s := range All {
+		// floor((2 * base + IV + floor(EV / 4)) * level / 100)
+		v := finish(s, (2*in.Base.Get(s)+in.IVs.Get(s)+in.EVs.Get(s)/4)*in.Level/100, in.Level)
+		if s != HP {
+			v = in.Nature.apply(s, v)
+		}
+		out.Set(s, v)
+	}
+	return out, nil
+}
+
+// finish adds the per-stat constant: level + 10 for HP, 5 for the others.
+func finish(s Stat, v, level int) int {
+	if s == HP {
+		return v + level + 10
+	}
+	return v + 5
+}
+
+// DVs returns dvs as generations 1 and 2 use them: Sp. Def takes the Special
+// DV of Sp. Atk and the HP DV is made of the lowest bit of the Attack,
+// Defense, Speed and Special DVs.
+func DVs(dvs Values) Values {
+	dvs.SpDefense = dvs.SpAttack
+	dvs.HP = (dvs.Attack&1)<<3 | (dvs.Defense&1)<<2 | (dvs.Speed&1)<<1 | dvs.SpAttack&1
+	return dvs
+}
+
+// Range is the lowest and highest value each stat can reach at a level.
+type Range struct {
+	Level int    `json:"level"`
+	Min   Values `json:"min" doc:"Lowest IVs, no EVs and a hindering nature"`
+	Max   Values `json:"max" doc:"Highest IVs, full EVs in the stat and a beneficial nature"`
+}
+
+// RangeAt returns the stat range of base at level in generation gen. Each
+// stat's extreme is computed on its own, so Max is not one reachable spread:
+// EVs are capped at 510 in total and a nature raises a single stat.
+func RangeAt(gen, level int, base Values) (Range, error) {
+	r := Range{Level: level}
+	maxIV, maxEV := MaxIVFor(gen), MaxEVFor(gen)
+	for _, s := range All {
+		lo, hi := Neutral, Neutral
+		if gen >= ModernGeneration && s != HP {
+			lo, hi = Nature{Name: "hindering", Up: other(s), Down: s}, Nature{Name: "beneficial", Up: s, Down: other(s)}
+		}
+		min, err := Calculate(Input{Generation: gen, Level: level, Base: base, Nature: lo})
+		if err != nil {
+			return Range{}, err
+		}
+		var evs Values
+		evs.Set(s, maxEV)
+		max, err := Calculate(Input{Generation: gen, Level: level, Base: base, IVs: Uniform(maxIV), EVs: evs, Nature: hi})
+		if err != nil {
+			return Range{}, err
+		}
+		r.Min.Set(s, min.Get(s))
+		r.Max.Set(s, max.Get(s))
+	}
+	return r, nil
+}
+
+// other returns a stat other than s and HP, for natures built by RangeAt.
+func other(s Stat) Stat {
+	if s == Attack {
+		return Defense
+	}
+	return Attack
+}
+
+// MaxIVFor returns the highest IV (DV before generation 3) of gen.
+func MaxIVFor(gen int) int {
+	if gen < ModernGeneration {
+		return MaxDV
+	}
+	return MaxIV
+}
+
+// MaxEVFor returns the highest EV of one stat (stat experience before
+// generation 3) in gen.
+func MaxEVFor(gen int) int {
+	switch {
+	case gen < ModernGeneration:
+		return MaxStatExp
+	case gen < firstGen252EV:
+		return maxEVGen3
+	}
+	return maxEVModern
+}
+
+func (in Input) validate() error {
+	if in.Generation < FirstGeneration || in.Generation > LatestGeneration {
+		return fmt.Errorf("generation %d: %w", in.Generation, ErrInvalidInput)
+	}
+	if in.Level < 1 || in.Level > 100 {
+		return fmt.Errorf("level %d: %w", in.Level, ErrInvalidInput)
+	}
+	if in.Generation < ModernGeneration && !in.Nature.Neutral() {
+		return fmt.Errorf("nature %s before generation %d: %w", in.Nature.Name, ModernGeneration, ErrInvalidInput)
+	}
+	maxIV, maxEV := MaxIVFor(in.Generation), MaxEVFor(in.Generation)
+	for _, s := range All {
+		if iv := in.IVs.Get(s); iv < 0 || iv > maxIV {
+			return fmt.Errorf("%s IV %d: %w", s, iv, ErrInvalidInput)
+		}
+		if ev := in.EVs.Get(s); ev < 0 || ev > maxEV {
+			return fmt.Errorf("%s EV %d: %w", s, ev, ErrInvalidInput)
+		}
+		if in.Base.Get(s) < 1 {
+			return fmt.Errorf("%s base stat %d: %w", s, in.Base.Get(s), ErrInvalidInput)
+		}
+	}
+	if in.Generation >= ModernGeneration && in.EVs.Total() > MaxEVTotal {
+		return fmt.Errorf("EV total %d: %w", in.EVs.Total(), ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	adamant, _ := ParseNature("adamant")
+	tests := []struct {
+		name string
+		in   Input
+		want Values
+	}{
+		{
+			// The worked example on Bulbapedia: a level 78 Adamant Garchomp.
+			name: "modern",
+			in: Input{
+				Generation: 9, Level: 78,
+				Base:   Values{108, 130, 95, 80, 85, 102},
+				IVs:    Values{24, 12, 30, 16, 23, 5},
+				EVs:    Values{74, 190, 91, 48, 84, 23},
+				Nature: adamant,
+			},
+			want: Values{289, 278, 193, 135, 171, 171},
+		},
+		{
+			name: "modern neutral",
+			in:   Input{Generation: 9, Level: 50, Base: Values{35, 55, 40, 50, 50, 90}, IVs: Uniform(31)},
+			want: Values{110, 75, 60, 70, 70, 110},
+		},
+		{
+			// Mewtwo with maximum DVs and stat experience: 416 HP as in
+			// Red and Blue.
+			name: "gen 1 max",
+			in:   Input{Generation: 1, Level: 100, Base: Values{106, 110, 90, 154, 90, 130}, IVs: Uniform(MaxDV), EVs: Uniform(MaxStatExp)},
+			want: Values{416, 319, 279, 407, 279, 359},
+		},
+		{
+			// An even Attack DV clears the HP DV's top bit: HP DV 7.
+			name: "gen 2 derived HP DV",
+			in:   Input{Generation: 2, Level: 100, Base: Values{100, 100, 100, 100, 100, 100}, IVs: Values{15, 14, 15, 15, 0, 15}},
+			want: Values{324, 233, 235, 235, 235, 235},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Calculate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateInvalid(t *testing.T) {
+	base := Uniform(100)
+	modest, _ := ParseNature("modest")
+	tests := []struct {
+		name string
+		in   Input
+	}{
+		{"generation 0", Input{Generation: 0, Level: 50, Base: base}},
+		{"level 101", Input{Generation: 9, Level: 101, Base: base}},
+		{"IV 32", Input{Generation: 9, Level: 50, Base: base, IVs: Values{Speed: 32}}},
+		{"DV 16", Input{Generation: 2, Level: 50, Base: base, IVs: Values{Attack: 16}}},
+		{"EV 253", Input{Generation: 9, Level: 50, Base: base, EVs: Values{Attack: 253}}},
+		{"EV total", Input{Generation: 9, Level: 50, Base: base, EVs: Values{252, 252, 8, 0, 0, 0}}},
+		{"nature in gen 2", Input{Generation: 2, Level: 50, Base: base, Nature: modest}},
+		{"no base stat", Input{Generation: 9, Level: 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Calculate(tt.in); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("err = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestRangeAt(t *testing.T) {
+	pikachu := Values{35, 55, 40, 50, 50, 90}
+	tests := []struct {
+		gen, level int
+		min, max   Values
+	}{
+		{9, 50, Values{95, 54, 40, 49, 49, 85}, Values{142, 117, 101, 112, 112, 156}},
+		{9, 100, Values{180, 103, 76, 94, 94, 166}, Values{274, 229, 196, 218, 218, 306}},
+		{5, 100, Values{180, 103, 76, 94, 94, 166}, Values{274, 229, 196, 218, 218, 306}},
+		{1, 100, Values{180, 115, 85, 105, 105, 185}, Values{274, 209, 179, 199, 199, 279}},
+	}
+	for _, tt := range tests {
+		r, err := RangeAt(tt.gen, tt.level, pikachu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Min != tt.min || r.Max != tt.max {
+			t.Errorf("RangeAt(%d, %d) = %+v-%+v, want %+v-%+v", tt.gen, tt.level, r.Min, r.Max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestParseNature(t *testing.T) {
+	n, err := ParseNature(" JOLLY ")
+	if err != nil || n.Up != Speed || n.Down != SpAttack {
+		t.Errorf("ParseNature(JOLLY) = %+v, %v", n, err)
+	}
+	if _, err := ParseNature("grumpy"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("ParseNature(grumpy) err = %v, want ErrInvalidInput", err)
+	}
+	if len(Natures) != 25 {
+		t.Errorf("%d natures, want 25", len(Natures))
+	}
+}
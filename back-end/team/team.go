@@ -0,0 +1,282 @@
+// Package team analyzes a party of up to six Pokemon: which attacking types
+// threaten it, which defending types its STAB moves cannot hit hard, its
+// average stats, and which other Pokemon would cover its worst weakness.
+package team
+
+import (
+	"GO-Mongo/models"
+	"GO-Mongo/typechart"
+	"math"
+	"slices"
+	"strings"
+)
+
+// MaxSize is the largest party the games allow.
+const MaxSize = 6
+
+// maxSuggestions bounds Analysis.Suggestions.
+const maxSuggestions = 5
+
+// Member identifies a Pokemon in an analysis.
+type Member struct {
+	DexNumber string   `json:"dex_number"`
+	Name      string   `json:"name"`
+	Types     []string `json:"types"`
+}
+
+// TypeCoverage counts how the team's members take hits of one attacking type.
+type TypeCoverage struct {
+	Type    string   `json:"type"`
+	Weak    []string `json:"weak" doc:"Members taking more than 1x"`
+	Resist  []string `json:"resist" doc:"Members taking between 0x and 1x"`
+	Immune  []string `json:"immune" doc:"Members taking 0x"`
+	Neutral int      `json:"neutral"`
+}
+
+// Defense is the team's aggregate defensive coverage.
+type Defense struct {
+	// ByType has one entry per attacking type, in chart order.
+	ByType []TypeCoverage `json:"by_type"`
+	// SharedWeaknesses are the attacking types more than one member is weak
+	// to, most members first.
+	SharedWeaknesses []TypeCoverage `json:"shared_weaknesses"`
+	// Uncovered are the attacking types no member resists or is immune to.
+	Uncovered []string `json:"uncovered"`
+	// BiggestWeakness is the attacking type with the most weak members net
+	// of those that resist it; empty when nothing threatens the team.
+	BiggestWeakness string `json:"biggest_weakness"`
+}
+
+// Offense is the coverage of the members' STAB (same type attack bonus)
+// types.
+type Offense struct {
+	STABTypes []string `json:"stab_types"`
+	// SuperEffective maps each defending type to the STAB types that hit it
+	// for more than 1x.
+	SuperEffective map[string][]string `json:"super_effective"`
+	// Uncovered are the defending types no STAB type hits super effectively.
+	Uncovered []string `json:"uncovered"`
+}
+
+// StatAverages are the members' mean base stats.
+type StatAverages struct {
+	HP        float64 `json:"hp"`
+	Attack    float64 `json:"attack"`
+	Defense   float64 `json:"defense"`
+	SpAttack  float64 `json:"sp_attack"`
+	SpDefense float64 `json:"sp_defense"`
+	Speed     float64 `json:"speed"`
+	Total     float64 `json:"total"`
+}
+
+// StatProfile summarizes the members' base stats.
+type StatProfile struct {
+	Average   StatAverages `json:"average"`
+	Strongest string       `json:"strongest" doc:"Stat with the highest average"`
+	Weakest   string       `json:"weakest" doc:"Stat with the lowest average"`
+}
+
+// Suggestion is a Pokemon outside the team that takes reduced damage from
+// the team's biggest weakness.
+type Suggestion struct {
+	Member
+	Multiplier float64 `json:"multiplier" doc:"Damage it takes from the biggest weakness"`
+	// AlsoResists lists the team's other shared weaknesses it resists.
+	AlsoResists   []string `json:"also_resists"`
+	BaseStatTotal int      `json:"base_stat_total"`
+}
+
+// Analysis is the result of Analyze.
+type Analysis struct {
+	Members []Member    `json:"members"`
+	Defense Defense     `json:"defense"`
+	Offense Offense     `json:"offense"`
+	Stats   StatProfile `json:"stats"`
+	// Suggestions patch Defense.BiggestWeakness, best first.
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// Analyze evaluates members with chart. Types a member has that the chart
+// lacks are ignored. Suggestions are drawn from pool, excluding members.
+func Analyze(chart *typechart.Chart, members, pool []models.Pokemon) Analysis {
+	a := Analysis{Members: make([]Member, len(members))}
+	for i, p := range members {
+		a.Members[i] = member(chart, p)
+	}
+	a.Defense = defense(chart, a.Members)
+	a.Offense = offense(chart, a.Members)
+	a.Stats = statProfile(members)
+	a.Suggestions = suggest(chart, a.Defense, members, pool)
+	return a
+}
+
+func member(chart *typechart.Chart, p models.Pokemon) Member {
+	m := Member{DexNumber: p.DexNumber, Name: p.Name, Types: []string{}}
+	for _, t := range p.Types() {
+		if canonical, ok := chart.Canonical(t); ok {
+			m.Types = append(m.Types, canonical)
+		}
+	}
+	return m
+}
+
+func defense(chart *typechart.Chart, members []Member) Defense {
+	d := Defense{ByType: []TypeCoverage{}, SharedWeaknesses: []TypeCoverage{}, Uncovered: []string{}}
+	bestScore := 0
+	for _, attacking := range chart.Types() {
+		tc := TypeCoverage{Type: attacking, Weak: []string{}, Resist: []string{}, Immune: []string{}}
+		for _, m := range members {
+			x, _ := chart.Effectiveness(attacking, m.Types...)
+			switch {
+			case x == 0:
+				tc.Immune = append(tc.Immune, m.Name)
+			case x > 1:
+				tc.Weak = append(tc.Weak, m.Name)
+			case x < 1:
+				tc.Resist = append(tc.Resist, m.Name)
+			default:
+				tc.Neutral++
+			}
+		}
+		d.ByType = append(d.ByType, tc)
+		if len(tc.Weak) > 1 {
+			d.SharedWeaknesses = append(d.SharedWeaknesses, tc)
+		}
+		if len(tc.Resist)+len(tc.Immune) == 0 {
+			d.Uncovered = append(d.Uncovered, attacking)
+		}
+		if score := len(tc.Weak) - len(tc.Resist) - len(tc.Immune); len(tc.Weak) > 0 && score > bestScore {
+			bestScore, d.BiggestWeakness = score, attacking
+		}
+	}
+	slices.SortStableFunc(d.SharedWeaknesses, func(a, b TypeCoverage) int {
+		return len(b.Weak) - len(a.Weak)
+	})
+	return d
+}
+
+func offense(chart *typechart.Chart, members []Member) Offense {
+	o := Offense{STABTypes: []string{}, SuperEffective: map[string][]string{}, Uncovered: []string{}}
+	for _, m := range members {
+		for _, t := range m.Types {
+			if !slices.Contains(o.STABTypes, t) {
+				o.STABTypes = append(o.STABTypes, t)
+			}
+		}
+	}
+	for _, defending := range chart.Types() {
+		var hitters []string
+		for _, attacking := range o.STABTypes {
+			if x, _ := chart.Effectiveness(attacking, defending); x > 1 {
+				hitters = append(hitters, attacking)
+			}
+		}
+		if len(hitters) == 0 {
+			o.Uncovered = append(o.Uncovered, defending)
+			continue
+		}
+		o.SuperEffective[defending] = hitters
+	}
+	return o
+}
+
+func statProfile(members []models.Pokemon) StatProfile {
+	var sum models.BaseStats
+	for _, p := range members {
+		b := p.BaseStats()
+		sum.HP += b.HP
+		sum.Attack += b.Attack
+		sum.Defense += b.Defense
+		sum.SpAttack += b.SpAttack
+		sum.SpDefense += b.SpDefense
+		sum.Speed += b.Speed
+		sum.Total += b.Total
+	}
+	n := float64(max(len(members), 1))
+	avg := func(total int) float64 { return math.Round(float64(total)/n*10) / 10 }
+	profile := StatProfile{Average: StatAverages{
+		HP:        avg(sum.HP),
+		Attack:    avg(sum.Attack),
+		Defense:   avg(sum.Defense),
+		SpAttack:  avg(sum.SpAttack),
+		SpDefense: avg(sum.SpDefense),
+		Speed:     avg(sum.Speed),
+		Total:     avg(sum.Total),
+	}}
+
+	stats := []struct {
+		name  string
+		value float64
+	}{
+		{"hp", profile.Average.HP},
+		{"attack", profile.Average.Attack},
+		{"defense", profile.Average.Defense},
+		{"sp_attack", profile.Average.SpAttack},
+		{"sp_defense", profile.Average.SpDefense},
+		{"speed", profile.Average.Speed},
+	}
+	high, low := stats[0], stats[0]
+	for _, s := range stats[1:] {
+		if s.value > high.value {
+			high = s
+		}
+		if s.value < low.value {
+			low = s
+		}
+	}
+	profile.Strongest, profile.Weakest = high.name, low.name
+	return profile
+}
+
+// suggest ranks the pool by the damage taken from the biggest weakness, then
+// by how many other shared weaknesses they resist, then by base stat total.
+func suggest(chart *typechart.Chart, d Defense, members, pool []models.Pokemon) []Suggestion {
+	out := []Suggestion{}
+	if d.BiggestWeakness == "" {
+		return out
+	}
+	onTeam := make(map[string]bool, len(members))
+	for _, p := range members {
+		onTeam[p.DexNumber] = true
+	}
+
+	for _, p := range pool {
+		if onTeam[p.DexNumber] {
+			continue
+		}
+		m := member(chart, p)
+		x, _ := chart.Effectiveness(d.BiggestWeakness, m.Types...)
+		if x >= 1 {
+			continue
+		}
+		s := Suggestion{Member: m, Multiplier: x, AlsoResists: []string{}, BaseStatTotal: p.BaseStats().Total}
+		for _, w := range d.SharedWeaknesses {
+			if w.Type == d.BiggestWeakness {
+				continue
+			}
+			if y, _ := chart.Effectiveness(w.Type, m.Types...); y < 1 {
+				s.AlsoResists = append(s.AlsoResists, w.Type)
+			}
+		}
+		out = append(out, s)
+	}
+
+	slices.SortStableFunc(out, func(a, b Suggestion) int {
+		switch {
+		case a.Multiplier != b.Multiplier:
+			if a.Multiplier < b.Multiplier {
+				return -1
+			}
+			return 1
+		case len(a.AlsoResists) != len(b.AlsoResists):
+			return len(b.AlsoResists) - len(a.AlsoResists)
+		case a.BaseStatTotal != b.BaseStatTotal:
+			return b.BaseStatTotal - a.BaseStatTotal
+		}
+		return strings.Compare(a.DexNumber, b.DexNumber)
+	})
+	if len(out) > maxSuggestions {
+		out = out[:maxSuggestions]
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package typechart
+
+import "strings"
+
+// effect lists the non-neutral multipliers of each attacking type against
+// each defending type; every pair not listed is 1x.
+type effect map[string]map[string]float64
+
+// modern is the chart from generation 6 on.
+var modern = effect{
+	"Normal":   {"Rock": 0.5, "Ghost": 0, "Steel": 0.5},
+	"Fire":     {"Fire": 0.5, "Water": 0.5, "Grass": 2, "Ice": 2, "Bug": 2, "Rock": 0.5, "Dragon": 0.5, "Steel": 2},
+	"Water":    {"Fire": 2, "Water": 0.5, "Grass": 0.5, "Ground": 2, "Rock": 2, "Dragon": 0.5},
+	"Electric": {"Water": 2, "Electric": 0.5, "Grass": 0.5, "Ground": 0, "Flying": 2, "Dragon": 0.5},
+	"Grass":    {"Fire": 0.5, "Water": 2, "Grass": 0.5, "Poison": 0.5, "Ground": 2, "Flying": 0.5, "Bug": 0.5, "Rock": 2, "Dragon": 0.5, "Steel": 0.5},
+	"Ice":      {"Fire": 0.5, "Water": 0.5, "Grass": 2, "Ice": 0.5, "Ground": 2, "Flying": 2, "Dragon": 2, "Steel": 0.5},
+	"Fighting": {"Normal": 2, "Ice": 2, "Poison": 0.5, "Flying": 0.5, "Psychic": 0.5, "Bug": 0.5, "Rock": 2, "Ghost": 0, "Dark": 2, "Steel": 2, "Fairy": 0.5},
+	"Poison":   {"Grass": 2, "Poison": 0.5, "Ground": 0.5, "Rock": 0.5, "Ghost": 0.5, "Steel": 0, "Fairy": 2},
+	"Ground":   {"Fire": 2, "Electric": 2, "Grass": 0.5, "Poison": 2, "Flying": 0, "Bug": 0.5, "Rock": 2, "Steel": 2},
+	"Flying":   {"Electric": 0.5, "Grass": 2, "Fighting": 2, "Bug": 2, "Rock": 0.5, "Steel": 0.5},
+	"Psychic":  {"Fighting": 2, "Poison": 2, "Psychic": 0.5, "Dark": 0, "Steel": 0.5},
+	"Bug":      {"Fire": 0.5, "Grass": 2, "Fighting": 0.5, "Poison": 0.5, "Flying": 0.5, "Psychic": 2, "Ghost": 0.5, "Dark": 2, "Steel": 0.5, "Fairy": 0.5},
+	"Rock":     {"Fire": 2, "Ice": 2, "Fighting": 0.5, "Ground": 0.5, "Flying": 2, "Bug": 2, "Steel": 0.5},
+	"Ghost":    {"Normal": 0, "Psychic": 2, "Ghost": 2, "Dark": 0.5},
+	"Dragon":   {"Dragon": 2, "Steel": 0.5, "Fairy": 0},
+	"Dark":     {"Fighting": 0.5, "Psychic": 2, "Ghost": 2, "Dark": 0.5, "Fairy": 0.5},
+	"Steel":    {"Fire": 0.5, "Water": 0.5, "Electric": 0.5, "Ice": 2, "Rock": 2, "Steel": 0.5, "Fairy": 2},
+	"Fairy":    {"Fire": 0.5, "Fighting": 2, "Poison": 0.5, "Dragon": 2, "Dark": 2, "Steel": 0.5},
+}
+
+// allTypes is the games' canonical type order.
+var allTypes = []string{
+	"Normal", "Fire", "Water", "Electric", "Grass", "Ice", "Fighting", "Poison", "Ground",
+	"Flying", "Psychic", "Bug", "Rock", "Ghost", "Dragon", "Dark", "Steel", "Fairy",
+}
+
+var (
+	gen6 = build(6, allTypes, modern, nil)
+
+	// Generations 2 to 5: no Fairy, and Steel also resists Ghost and Dark.
+	gen2 = build(2, allTypes[:17], modern, effect{
+		"Ghost": {"Steel": 0.5},
+		"Dark":  {"Steel": 0.5},
+	})
+
+	// Generation 1: no Dark or Steel. Bug and Poison were super effective
+	// against each other, Ghost could not hit Psychic (a bug in the games
+	// that the chart reproduces) and Fire did not resist Ice.
+	gen1 = build(1, allTypes[:15], modern, effect{
+		"Bug":    {"Poison": 2},
+		"Poison": {"Bug": 2},
+		"Ghost":  {"Psychic": 0},
+		"Ice":    {"Fire": 1},
+	})
+)
+
+// build makes the chart over types from base with overrides applied.
+func build(since int, types []string, base, overrides effect) *Chart {
+	c := &Chart{Since: since, types: types, index: make(map[string]int, len(types))}
+	for i, t := range types {
+		c.index[strings.ToLower(t)] = i
+	}
+	c.matrix = make([][]float64, len(types))
+	for a, attacker := range types {
+		c.matrix[a] = make([]float64, len(types))
+		for d, defender := range types {
+			m, ok := overrides[attacker][defender]
+			if !ok {
+				m, ok = base[attacker][defender]
+			}
+			if !ok {
+				m = 1
+			}
+			c.matrix[a][d] = m
+		}
+	}
+	return c
+}
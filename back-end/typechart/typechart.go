@@ -0,0 +1,193 @@
+// Package typechart holds the type effectiveness charts of the main series
+// games and the matchup calculations built on them. The chart changed twice:
+// generation 2 added Dark and Steel and fixed several generation 1 entries,
+// and generation 6 added Fairy and dropped Steel's resistance to Ghost and
+// Dark.
+package typechart
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LatestGeneration is the newest generation the charts cover.
+const LatestGeneration = 9
+
+var (
+	ErrUnknownType       = errors.New("unknown type")
+	ErrUnknownGeneration = errors.New("unknown generation")
+)
+
+// Chart is the effectiveness matrix of one range of generations.
+type Chart struct {
+	// Since is the first generation that uses this chart.
+	Since  int
+	types  []string
+	index  map[string]int // lower-case name to row/column
+	matrix [][]float64    // [attacking][defending]
+}
+
+// Multiplier is the damage multiplier of an attacking type against a
+// defender.
+type Multiplier struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Matchups sorts the attacking types by their effect on a defender.
+type Matchups struct {
+	Weaknesses  []Multiplier `json:"weaknesses"`  // more than 1x, strongest first
+	Resistances []Multiplier `json:"resistances"` // between 0x and 1x, most resisted first
+	Immunities  []string     `json:"immunities"`  // 0x
+}
+
+// ForGeneration returns the chart used by generation gen.
+func ForGeneration(gen int) (*Chart, error) {
+	switch {
+	case gen == 1:
+		return gen1, nil
+	case gen >= 2 && gen <= 5:
+		return gen2, nil
+	case gen >= 6 && gen <= LatestGeneration:
+		return gen6, nil
+	}
+	return nil, fmt.Errorf("generation %d: %w", gen, ErrUnknownGeneration)
+}
+
+// Latest returns the chart of the current games.
+func Latest() *Chart { return gen6 }
+
+// Types returns the types of the chart in the games' canonical order.
+func (c *Chart) Types() []string { return slices.Clone(c.types) }
+
+// Canonical returns the chart's spelling of t, matched case-insensitively
+// and ignoring surrounding space.
+func (c *Chart) Canonical(t string) (string, bool) {
+	i, ok := c.index[strings.ToLower(strings.TrimSpace(t))]
+	if !ok {
+		return "", false
+	}
+	return c.types[i], true
+}
+
+// Effectiveness returns the multiplier of attacking against a defender with
+// the given one or two types.
+func (c *Chart) Effectiveness(attacking string, defending ...string) (float64, error) {
+	a, err := c.lookup(attacking)
+	if err != nil {
+		return 0, err
+	}
+	m := 1.0
+	for _, d := range defending {
+		j, err := c.lookup(d)
+		if err != nil {
+			return 0, err
+		}
+		m *= c.matrix[a][j]
+	}
+	return m, nil
+}
+
+// Attacking returns the multiplier of attacking against each single type.
+func (c *Chart) Attacking(attacking string) ([]Multiplier, error) {
+	a, err := c.lookup(attacking)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Multiplier, len(c.types))
+	for j, t := range c.types {
+		out[j] = Multiplier{Type: t, Multiplier: c.matrix[a][j]}
+	}
+	return out, nil
+}
+
+// Defending returns the multiplier of every attacking type against a
+// defender with the given one or two types.
+func (c *Chart) Defending(defending ...string) ([]Multiplier, error) {
+	cols := make([]int, len(defending))
+	for i, d := range defending {
+		j, err := c.lookup(d)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = j
+	}
+	out := make([]Multiplier, len(c.types))
+	for a, t := range c.types {
+		m := 1.0
+		for _, j := range cols {
+			m *= c.matrix[a][j]
+		}
+		out[a] = Multiplier{Type: t, Multiplier: m}
+	}
+	return out, nil
+}
+
+// Matchups classifies every attacking type against a defender with the given
+// types.
+func (c *Chart) Matchups(defending ...string) (Matchups, error) {
+	all, err := c.Defending(defending...)
+	if err != nil {
+		return Matchups{}, err
+	}
+	m := Matchups{Weaknesses: []Multiplier{}, Resistances: []Multiplier{}, Immunities: []string{}}
+	for _, x := range all {
+		switch {
+		case x.Multiplier == 0:
+			m.Immunities = append(m.Immunities, x.Type)
+		case x.Multiplier > 1:
+			m.Weaknesses = append(m.Weaknesses, x)
+		case x.Multiplier < 1:
+			m.Resistances = append(m.Resistances, x)
+		}
+	}
+	sortByMultiplier(m.Weaknesses, true)
+	sortByMultiplier(m.Resistances, false)
+	return m, nil
+}
+
+// BestAttackers returns the attacking types that deal the most damage to a
+// defender with the given types: every super effective type, strongest
+// first, or the neutral types when nothing is super effective.
+func (c *Chart) BestAttackers(defending ...string) ([]Multiplier, error) {
+	all, err := c.Defending(defending...)
+	if err != nil {
+		return nil, err
+	}
+	sortByMultiplier(all, true)
+	best := []Multiplier{}
+	if len(all) == 0 {
+		return best, nil
+	}
+	top := all[0].Multiplier
+	for _, x := range all {
+		if x.Multiplier == top || (top > 1 && x.Multiplier > 1) {
+			best = append(best, x)
+		}
+	}
+	return best, nil
+}
+
+func (c *Chart) lookup(t string) (int, error) {
+	i, ok := c.index[strings.ToLower(strings.TrimSpace(t))]
+	if !ok {
+		return 0, fmt.Errorf("%q in the generation %d chart: %w", t, c.Since, ErrUnknownType)
+	}
+	return i, nil
+}
+
+// sortByMultiplier orders ms by multiplier, keeping the chart order between
+// equal multipliers.
+func sortByMultiplier(ms []Multiplier, descending bool) {
+	slices.SortStableFunc(ms, func(a, b Multiplier) int {
+		if a.Multiplier == b.Multiplier {
+			return 0
+		}
+		if (a.Multiplier > b.Multiplier) == descending {
+			return -1
+		}
+		return 1
+	})
+}
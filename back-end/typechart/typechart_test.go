@@ -0,0 +1,133 @@
+package typechart
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type matchup struct {
+	attacking string
+	defending []string
+	want      float64
+}
+
+func TestEffectiveness(t *testing.T) {
+	tests := []struct {
+		name  string
+		gens  []int
+		types int
+		cases []matchup
+	}{
+		{"gen 1", []int{1}, 15, []matchup{
+			{"Ghost", []string{"Psychic"}, 0},
+			{"Bug", []string{"Poison"}, 2},
+			{"Poison", []string{"Bug"}, 2},
+			{"Ice", []string{"Fire"}, 1},
+			{"Normal", []string{"Ghost"}, 0},
+			{"Electric", []string{"Ground", "Flying"}, 0},
+			{"Ice", []string{"Ground", "Flying"}, 4},
+			{"Water", []string{"Fire", "Rock"}, 4},
+		}},
+		{"gens 2-5", []int{2, 3, 4, 5}, 17, []matchup{
+			{"Ghost", []string{"Steel"}, 0.5},
+			{"Dark", []string{"Steel"}, 0.5},
+			{"Ghost", []string{"Psychic"}, 2},
+			{"Bug", []string{"Poison"}, 0.5},
+			{"Poison", []string{"Bug"}, 1},
+			{"Ice", []string{"Fire"}, 0.5},
+			{"Psychic", []string{"Dark"}, 0},
+			{"Poison", []string{"Steel"}, 0},
+			{"Electric", []string{"Ground", "Flying"}, 0},
+			{"Ice", []string{"Ground", "Flying"}, 4},
+			{"Fire", []string{"Bug", "Steel"}, 4},
+		}},
+		{"gens 6-9", []int{6, 7, 8, 9}, 18, []matchup{
+			{"Ghost", []string{"Steel"}, 1},
+			{"Dark", []string{"Steel"}, 1},
+			{"Dragon", []string{"Fairy"}, 0},
+			{"Fairy", []string{"Dragon"}, 2},
+			{"Fairy", []string{"Dark"}, 2},
+			{"Poison", []string{"Fairy"}, 2},
+			{"Steel", []string{"Fairy"}, 2},
+			{"Fighting", []string{"Fairy"}, 0.5},
+			{"Dragon", []string{"Dragon", "Fairy"}, 0},
+			{"Electric", []string{"Ground", "Flying"}, 0},
+			{"Ice", []string{"Ground", "Flying"}, 4},
+			{"Grass", []string{"Fire", "Dragon"}, 0.25},
+		}},
+	}
+	for _, tt := range tests {
+		for _, gen := range tt.gens {
+			c, err := ForGeneration(gen)
+			if err != nil {
+				t.Fatalf("ForGeneration(%d): %v", gen, err)
+			}
+			if len(c.Types()) != tt.types {
+				t.Errorf("%s: generation %d has %d types, want %d", tt.name, gen, len(c.Types()), tt.types)
+			}
+			for _, m := range tt.cases {
+				got, err := c.Effectiveness(m.attacking, m.defending...)
+				if err != nil {
+					t.Errorf("%s: generation %d: Effectiveness(%s, %v): %v", tt.name, gen, m.attacking, m.defending, err)
+					continue
+				}
+				if got != m.want {
+					t.Errorf("%s: generation %d: Effectiveness(%s, %v) = %v, want %v", tt.name, gen, m.attacking, m.defending, got, m.want)
+				}
+			}
+		}
+	}
+}
+
+func TestUnknownTypes(t *testing.T) {
+	tests := []struct {
+		gen  int
+		typ  string
+		want bool
+	}{
+		{1, "Dark", false},
+		{1, "Steel", false},
+		{2, "Steel", true},
+		{5, "Fairy", false},
+		{6, "Fairy", true},
+		{9, " fairy ", true},
+	}
+	for _, tt := range tests {
+		c, _ := ForGeneration(tt.gen)
+		_, err := c.Effectiveness("Normal", tt.typ)
+		if known := err == nil; known != tt.want {
+			t.Errorf("generation %d: Effectiveness(Normal, %q) error = %v, want known %v", tt.gen, tt.typ, err, tt.want)
+		}
+		if err != nil && !errors.Is(err, ErrUnknownType) {
+			t.Errorf("generation %d: Effectiveness(Normal, %q) error = %v, want ErrUnknownType", tt.gen, tt.typ, err)
+		}
+	}
+}
+
+func TestForGeneration(t *testing.T) {
+	for _, gen := range []int{0, LatestGeneration + 1} {
+		if _, err := ForGeneration(gen); !errors.Is(err, ErrUnknownGeneration) {
+			t.Errorf("ForGeneration(%d) error = %v, want ErrUnknownGeneration", gen, err)
+		}
+	}
+	if gen6, _ := ForGeneration(LatestGeneration); gen6 != Latest() {
+		t.Errorf("ForGeneration(%d) is not Latest()", LatestGeneration)
+	}
+}
+
+func TestMatchups(t *testing.T) {
+	got, err := Latest().Matchups("Ground", "Flying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Immunities, []string{"Electric", "Ground"}) {
+		t.Errorf("Immunities = %v, want [Electric Ground]", got.Immunities)
+	}
+	if len(got.Weaknesses) == 0 || got.Weaknesses[0] != (Multiplier{Type: "Ice", Multiplier: 4}) {
+		t.Errorf("Weaknesses = %v, want Ice 4x first", got.Weaknesses)
+	}
+	if slices.ContainsFunc(got.Weaknesses, func(m Multiplier) bool { return m.Type == "Ground" }) {
+		t.Errorf("Weaknesses = %v, Ground should be 0x, not a weakness", got.Weaknesses)
+	}
+}
@@ -1,9 +1,10 @@
-import React from 'react';
+import React, { useEffect, useState } from 'react';
 import { useNavigate } from 'react-router-dom';
 import { usePokemon } from '../contexts/SimplePokemonContext';
 import { LoadingSpinner } from '../components/ui/LoadingSpinner';
 import { EnhancedSearchBar } from '../components/ui/EnhancedSearchBar';
 import { PokemonImage } from '../components/ui/PokemonImage';
+import { pokemonService, FeaturedPokemon } from '../services/PokemonService';
 
 const HomePage: React.FC = () => {
   const navigate = useNavigate();
@@ -23,6 +24,20 @@ const HomePage: React.FC = () => {
     setViewMode,
     clearFilters
   } = usePokemon();
+  const [featured, setFeatured] = useState<FeaturedPokemon | null>(null);
+
+  // Pokemon of the day, picked by the API for the browser's time zone
+  useEffect(() => {
+    let cancelled = false;
+    pokemonService.getDailyPokemon().then((daily) => {
+      if (!cancelled) {
+        setFeatured(daily);
+      }
+    });
+    return () => {
+      cancelled = true;
+    };
+  }, []);
 
   const handlePokemonClick = (pokemon: any) => {
     // Extract number from dexNumber (e.g., "#0001" -> "1")
@@ -144,6 +159,56 @@ const HomePage: React.FC = () => {
           </div>
         </div>
 
+        {/* Pokemon of the Day */}
+        {featured && (
+          <div
+            onClick={() => handlePokemonClick(featured.pokemon)}
+            style={{
+              backgroundColor: '#ffffff',
+              borderRadius: '16px',
+              padding: '24px',
+              boxShadow: '0 4px 20px rgba(0,0,0,0.08)',
+              border: '1px solid #e8e8ed',
+              display: 'flex',
+              alignItems: 'center',
+              gap: '24px',
+              cursor: 'pointer'
+            }}
+          >
+            <PokemonImage
+              dexNumber={featured.pokemon.dexNumber}
+              name={featured.pokemon.name}
+              primaryType={featured.pokemon.types[0]?.name}
+              size="medium"
+            />
+            <div style={{ display: 'flex', flexDirection: 'column', gap: '8px', minWidth: 0 }}>
+              <span style={{ fontSize: '13px', fontWeight: '600', color: '#86868b', textTransform: 'uppercase', letterSpacing: '0.05em' }}>
+                Pokémon of the Day{featured.date && ` • ${featured.date}`}
+              </span>
+              <h2 style={{ fontSize: '28px', fontWeight: '600', color: '#1d1d1f', margin: 0 }}>
+                {featured.pokemon.name}
+                <span style={{ fontSize: '17px', fontWeight: '400', color: '#86868b', marginLeft: '8px' }}>
+                  {featured.pokemon.dexNumber}
+                </span>
+              </h2>
+              <div className="pokemon-types" style={{ justifyContent: 'flex-start' }}>
+                {featured.pokemon.types.map((type) => (
+                  <span
+                    key={type.name}
+                    className="pokemon-type-badge"
+                    style={{ backgroundColor: type.color }}
+                  >
+                    {type.name}
+                  </span>
+                ))}
+              </div>
+              <p style={{ fontSize: '15px', color: '#86868b', lineHeight: '1.5', margin: 0 }}>
+                {featured.pokemon.bio}
+              </p>
+            </div>
+          </div>
+        )}
+
         {/* Search and Filters */}
         <div style={{
           backgroundColor: '#ffffff',
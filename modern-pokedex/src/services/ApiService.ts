@@ -35,9 +35,59 @@ export interface PokemonRawData {
     speed: string;
 }
 
+// Typed Pokemon served by /api/v2 and the routes added after it
+export interface PokemonV2Data {
+    id: number;
+    dex_number: string;
+    name: string;
+    types: string[];
+    abilities: string[];
+    hidden_ability: string;
+    egg_groups: string[];
+    is_legendary: boolean;
+    bio: string;
+    base_stats: {
+        hp: number;
+        attack: number;
+        defense: number;
+        sp_attack: number;
+        sp_defense: number;
+        speed: number;
+        total: number;
+    };
+}
+
+// A Pokemon picked by /pokemon/daily or /pokemon/random
+export interface FeaturedPokemonData {
+    region: string;
+    date?: string;
+    time_zone?: string;
+    pokemon: PokemonV2Data;
+}
+
 export interface ApiResponse<T> {
     data?: T;
     error?: string;
+    problem?: ApiProblem;
+}
+
+// RFC 7807 problem+json body returned by the API for every error
+export interface ApiProblem {
+    type: string;
+    title: string;
+    status: number;
+    detail: string;
+    instance?: string;
+    code: string;
+    details?: { field: string; reason: string }[];
+    request_id?: string;
+}
+
+class ApiError extends Error {
+    constructor(message: string, public problem?: ApiProblem) {
+        super(message);
+        this.name = 'ApiError';
+    }
 }
 
 class ApiService {
@@ -46,7 +96,8 @@ class ApiService {
             const response = await fetch(`${API_BASE_URL}${url}`);
             
             if (!response.ok) {
-                throw new Error(`HTTP error! status: ${response.status}`);
+                const problem = await this.parseProblem(response);
+                throw new ApiError(problem?.detail ?? `HTTP error! status: ${response.status}`, problem);
             }
             
             const data = await response.json();
@@ -54,11 +105,23 @@ class ApiService {
         } catch (error) {
             console.error('API Error:', error);
             return { 
-                error: error instanceof Error ? error.message : 'Unknown error occurred' 
+                error: error instanceof Error ? error.message : 'Unknown error occurred',
+                problem: error instanceof ApiError ? error.problem : undefined
             };
         }
     }
 
+    private async parseProblem(response: Response): Promise<ApiProblem | undefined> {
+        if (!response.headers.get('Content-Type')?.includes('application/problem+json')) {
+            return undefined;
+        }
+        try {
+            return await response.json();
+        } catch {
+            return undefined;
+        }
+    }
+
     async getAllPokemon(): Promise<ApiResponse<PokemonRawData[]>> {
         return this.fetchWithErrorHandling<PokemonRawData[]>('/pokemon');
     }
@@ -96,6 +159,19 @@ class ApiService {
         return this.fetchWithErrorHandling<PokemonRawData[]>('/pokemon/legendary');
     }
 
+    async getDailyPokemon(params: {
+        timeZone?: string;
+        region?: string;
+    } = {}): Promise<ApiResponse<FeaturedPokemonData>> {
+        const searchParams = new URLSearchParams();
+
+        if (params.timeZone) searchParams.append('tz', params.timeZone);
+        if (params.region) searchParams.append('region', params.region);
+
+        const queryString = searchParams.toString();
+        return this.fetchWithErrorHandling<FeaturedPokemonData>(`/pokemon/daily${queryString ? `?${queryString}` : ''}`);
+    }
+
     async getStatsSummary(): Promise<ApiResponse<{
         totalPokemon: number;
         legendaryCount: number;
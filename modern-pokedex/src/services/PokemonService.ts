@@ -1,4 +1,4 @@
-import { apiService, PokemonRawData } from './ApiService';
+import { apiService, PokemonRawData, PokemonV2Data } from './ApiService';
 
 export interface Pokemon {
     id: number;
@@ -32,6 +32,13 @@ export interface SearchFilters {
     isLegendary?: boolean;
 }
 
+// The Pokemon of the day and where and when it was picked
+export interface FeaturedPokemon {
+    pokemon: Pokemon;
+    region: string;
+    date: string;
+}
+
 export interface PokemonServiceError {
     message: string;
     code: 'LOAD_ERROR' | 'NOT_FOUND' | 'INVALID_FILTER';
@@ -104,6 +111,30 @@ export class PokemonService {
         };
     }
 
+    private transformV2Data(data: PokemonV2Data): Pokemon {
+        return {
+            id: data.id,
+            dexNumber: data.dex_number,
+            name: data.name,
+            types: data.types.map(name => ({
+                name,
+                color: this.getTypeColor(name)
+            })),
+            abilities: data.abilities,
+            hiddenAbility: data.hidden_ability || undefined,
+            isLegendary: data.is_legendary,
+            bio: data.bio,
+            stats: {
+                hp: data.base_stats.hp,
+                attack: data.base_stats.attack,
+                defense: data.base_stats.defense,
+                spAttack: data.base_stats.sp_attack,
+                spDefense: data.base_stats.sp_defense,
+                speed: data.base_stats.speed
+            }
+        };
+    }
+
     /**
      * Load and process Pokemon data from the API
      */
@@ -275,6 +306,30 @@ export class PokemonService {
         }
     }
 
+    /**
+     * Get the Pokemon of the day for the browser's time zone
+     */
+    async getDailyPokemon(): Promise<FeaturedPokemon | null> {
+        try {
+            // Detail pages only serve Kanto, so the pick stays in Kanto
+            const response = await apiService.getDailyPokemon({
+                timeZone: Intl.DateTimeFormat().resolvedOptions().timeZone,
+                region: 'kanto'
+            });
+            if (response.error || !response.data) {
+                return null;
+            }
+            return {
+                pokemon: this.transformV2Data(response.data.pokemon),
+                region: response.data.region,
+                date: response.data.date ?? ''
+            };
+        } catch (error) {
+            console.error('Error fetching Pokemon of the day:', error);
+            return null;
+        }
+    }
+
     /**
      * Get Pokemon stats summary
      */
@@ -13,6 +13,7 @@ vi.mock('../ApiService', () => ({
     getAvailableTypes: vi.fn(),
     getLegendaryPokemon: vi.fn(),
     getStatsSummary: vi.fn(),
+    getDailyPokemon: vi.fn(),
   }
 }));
 
@@ -287,6 +288,46 @@ describe('PokemonService', () => {
     });
   });
 
+  describe('getDailyPokemon', () => {
+    it('should transform the typed Pokemon of the day', async () => {
+      mockApiService.getDailyPokemon.mockResolvedValue({
+        data: {
+          region: 'kanto',
+          date: '2026-10-18',
+          time_zone: 'UTC',
+          pokemon: {
+            id: 94,
+            dex_number: '#0094',
+            name: 'Gengar',
+            types: ['Ghost', 'Poison'],
+            abilities: ['Cursed Body'],
+            hidden_ability: '',
+            egg_groups: ['Amorphous'],
+            is_legendary: false,
+            bio: 'Gengar is a dual-type Ghost/Poison Pokémon.',
+            base_stats: { hp: 60, attack: 65, defense: 60, sp_attack: 130, sp_defense: 75, speed: 110, total: 500 }
+          }
+        }
+      });
+
+      const featured = await service.getDailyPokemon();
+
+      expect(featured?.region).toBe('kanto');
+      expect(featured?.date).toBe('2026-10-18');
+      expect(featured?.pokemon.id).toBe(94);
+      expect(featured?.pokemon.types.map(t => t.name)).toEqual(['Ghost', 'Poison']);
+      expect(featured?.pokemon.stats.spAttack).toBe(130);
+      expect(featured?.pokemon.hiddenAbility).toBeUndefined();
+      expect(mockApiService.getDailyPokemon).toHaveBeenCalledWith(expect.objectContaining({ region: 'kanto' }));
+    });
+
+    it('should return null when the request fails', async () => {
+      mockApiService.getDailyPokemon.mockResolvedValue({ error: 'Network error' });
+
+      expect(await service.getDailyPokemon()).toBeNull();
+    });
+  });
+
   describe('error handling', () => {
     it('should handle API errors gracefully', async () => {
       mockApiService.getAllPokemon.mockResolvedValue({ error: 'Network error' });